@@ -14,6 +14,9 @@ import (
 	// Domain
 	"github.com/monchh/annict-slack-bot/usecase"
 
+	// Utilities
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+
 	// Interfaces (Adapters)
 	"github.com/monchh/annict-slack-bot/interfaces/presenter"
 	"github.com/monchh/annict-slack-bot/interfaces/repository"
@@ -21,9 +24,17 @@ import (
 
 	// Infrastructure
 	"github.com/monchh/annict-slack-bot/infrastructure/annict"
+	"github.com/monchh/annict-slack-bot/infrastructure/cache"
 	"github.com/monchh/annict-slack-bot/infrastructure/config"
+	"github.com/monchh/annict-slack-bot/infrastructure/credential"
 	"github.com/monchh/annict-slack-bot/infrastructure/httpclient"
+	"github.com/monchh/annict-slack-bot/infrastructure/httpserver"
+	"github.com/monchh/annict-slack-bot/infrastructure/imageproxy"
+	"github.com/monchh/annict-slack-bot/infrastructure/oauth"
+	"github.com/monchh/annict-slack-bot/infrastructure/scheduler"
 	"github.com/monchh/annict-slack-bot/infrastructure/slack"
+	"github.com/monchh/annict-slack-bot/infrastructure/storage"
+	"github.com/monchh/annict-slack-bot/infrastructure/streaming"
 )
 
 func main() {
@@ -46,38 +57,208 @@ func main() {
 	slog.SetDefault(logger) // Set as default logger for the application
 	slog.Info("Configuration loaded successfully", slog.String("logLevel", cfg.LogLevel), slog.Bool("isDevelopment", cfg.IsDevelopment))
 
+	// Broadcast day (深夜アニメ) settings
+	if err := jst.SetDayRolloverHour(cfg.DayRolloverHour); err != nil {
+		log.Fatalf("FATAL: Invalid DAY_ROLLOVER_HOUR: %v", err)
+	}
+	jst.SetThirtyHourNotation(cfg.ThirtyHourNotation)
+
 	// Infrastructure Layer Instances
 	slog.Info("Initializing Infrastructure...")
-	annictAuthHttpClient := &http.Client{
-		Transport: &config.AnnictAuthTransport{
-			Token:     cfg.AnnictToken,
-			Transport: http.DefaultTransport,
-		},
-		Timeout: 30 * time.Second,
-	}
-	annictClient := annict.NewClient(annictAuthHttpClient, cfg.AnnictEndpoint, nil)
+	// Annict clients are created per access token: each Slack user has their own account.
+	newAnnictClient := func(token string) *annict.Client {
+		annictAuthHttpClient := &http.Client{
+			Transport: &config.AnnictAuthTransport{
+				Token:     token,
+				Transport: http.DefaultTransport,
+			},
+			Timeout: 30 * time.Second,
+		}
+		return annict.NewClient(annictAuthHttpClient, cfg.AnnictEndpoint, nil)
+	}
 	httpClient := httpclient.NewClient(cfg.ImageCheckTimeout)
+	store, err := storage.Open(context.Background(), cfg.StorageDBPath)
+	if err != nil {
+		log.Fatalf("FATAL: Error opening storage: %v", err)
+	}
+	defer store.Close()
+	// State files of versions before the database, imported once
+	legacyFiles := []struct {
+		what       string
+		path       string
+		importFile func(ctx context.Context, path string) (int, error)
+	}{
+		{what: "sent reminder(s)", path: cfg.ReminderHistoryFile, importFile: store.ImportReminderHistory},
+		{what: "scheduler importFile time(s)", path: cfg.ScheduleStateFile, importFile: store.ImportSchedulerState},
+		{what: "linked Annict token(s)", path: cfg.AnnictTokenStoreFile, importFile: store.ImportTokens},
+	}
+	for _, legacy := range legacyFiles {
+		if imported, err := legacy.importFile(context.Background(), legacy.path); err != nil {
+			slog.Warn(fmt.Sprintf("Error importing %s from %s: %v", legacy.what, legacy.path, err))
+		} else if imported > 0 {
+			slog.Info(fmt.Sprintf("Imported %d %s from %s", imported, legacy.what, legacy.path))
+		}
+	}
+	// Linked Annict tokens (optional; without the key everyone uses ANNICT_ACCESS_TOKEN)
+	var tokenStore usecase.TokenStore
+	var tokenKey []byte
+	if cfg.LinkingEnabled() {
+		tokenKey, err = credential.ParseEncryptionKey(cfg.AnnictTokenKey)
+		if err != nil {
+			log.Fatalf("FATAL: Invalid ANNICT_TOKEN_ENCRYPTION_KEY: %v (generate one with `openssl rand -base64 32`, or leave it empty to disable account linking)", err)
+		}
+		encryptedStore, err := credential.NewEncryptedTokenStore(store, tokenKey)
+		if err != nil {
+			log.Fatalf("FATAL: Error creating encrypted token store: %v", err)
+		}
+		tokenStore = encryptedStore
+	} else {
+		slog.Warn("ANNICT_TOKEN_ENCRYPTION_KEY is not set; account linking is disabled and all commands use ANNICT_ACCESS_TOKEN")
+	}
+	// Annict OAuth (optional; without it users link personal access tokens)
+	var oauthProvider usecase.OAuthProvider
+	var oauthStates usecase.LinkStateStore
+	if cfg.OAuthEnabled() {
+		oauthProvider = oauth.NewClient(oauth.Config{
+			ClientID:     cfg.OAuthClientID,
+			ClientSecret: cfg.OAuthClientSecret,
+			RedirectURL:  cfg.OAuthRedirectURL,
+			AuthorizeURL: cfg.OAuthAuthorizeURL,
+			TokenURL:     cfg.OAuthTokenURL,
+			RevokeURL:    cfg.OAuthRevokeURL,
+		}, &http.Client{Timeout: 30 * time.Second})
+		oauthStates = oauth.NewMemoryStateStore()
+	}
 
 	// Interfaces Layer Instances (Adapters)
 	slog.Info("Initializing Interfaces...")
-	annictRepo := repository.NewAnnictRepository(annictClient, logger)
+	pagination := repository.PaginationOptions{
+		PageSize: cfg.AnnictPageSize,
+		MaxPages: cfg.AnnictMaxPages,
+	}
+	accountVerifier := repository.NewAnnictAccountVerifier(newAnnictClient, logger)
+	// Cache of Annict query results, shared by the per-request repositories
+	var programCache *repository.ProgramCache
+	cacheTTL := repository.CacheTTL{
+		Programs: cfg.CacheProgramsTTL,
+		Library:  cfg.CacheLibraryTTL,
+		Stale:    cfg.CacheStaleTTL,
+	}
+	switch cfg.CacheBackend {
+	case "memory":
+		programCache = repository.NewProgramCache(cache.NewMemoryCache(), cacheTTL, logger)
+	case "sqlite":
+		programCache = repository.NewProgramCache(store, cacheTTL, logger)
+	case "none", "":
+		slog.Info("Annict response cache is disabled")
+	default:
+		log.Fatalf("FATAL: Invalid CACHE_BACKEND %q (memory, sqlite or none)", cfg.CacheBackend)
+	}
 
-	// Validator (shared)
-	httpValidator := validator.NewHTTPImageValidator(httpClient)
+	// Validator (shared, caches valid and broken images per URL)
+	httpValidator := validator.NewCachedImageValidator(
+		validator.NewHTTPImageValidator(httpClient),
+		cfg.ImageCacheValidTTL,
+		cfg.ImageCacheInvalidTTL,
+	)
+	// Image fallback chain: Annict images -> og:image of the official site -> placeholder
+	var ogFetcher usecase.OGImageFetcher
+	if cfg.ImageOGFallback {
+		ogFetcher = validator.NewHTTPOGImageFetcher(&http.Client{Timeout: 30 * time.Second}, cfg.ImageCacheValidTTL)
+	}
+	var imageProxy *imageproxy.Proxy
+	var imageProxyPort usecase.ImageProxy
+	if cfg.ImageProxyEnabled() {
+		// Thumbnails persist with the Annict cache when it uses SQLite.
+		var thumbnailCache usecase.CacheRepository = cache.NewMemoryCache()
+		if cfg.CacheBackend == "sqlite" {
+			thumbnailCache = store
+		}
+		// Thumbnail URLs posted to Slack must keep resolving after a restart, so the key is never generated.
+		signingKey := []byte(cfg.ImageProxySigningKey)
+		if len(signingKey) == 0 {
+			signingKey = tokenKey
+		}
+		if len(signingKey) == 0 {
+			log.Fatalf("FATAL: IMAGE_PROXY_BASE_URL requires IMAGE_PROXY_SIGNING_KEY (or ANNICT_TOKEN_ENCRYPTION_KEY) to sign thumbnail URLs; generate one with `openssl rand -base64 32`")
+		}
+		imageProxy = imageproxy.New(imageproxy.Config{
+			BaseURL:    cfg.ImageProxyBaseURL,
+			SigningKey: signingKey,
+			MaxWidth:   cfg.ImageProxyMaxWidth,
+			CacheTTL:   cfg.ImageProxyCacheTTL,
+		}, &http.Client{Timeout: 30 * time.Second}, thumbnailCache)
+		imageProxyPort = imageProxy
+	}
+	imageFallback := usecase.NewImageFallback(httpValidator, ogFetcher, imageProxyPort)
+	// Streaming services per work (optional local mapping file)
+	var streamingProvider usecase.StreamingProvider
+	if cfg.StreamingMappingFile != "" {
+		fileProvider, err := streaming.NewFileProvider(cfg.StreamingMappingFile)
+		if err != nil {
+			log.Fatalf("FATAL: Error loading streaming mapping: %v", err)
+		}
+		streamingProvider = fileProvider
+	}
 	// Presenter (handles combined output)
 	slackPresenter := presenter.NewSlackProgramPresenter(cfg.AnnictLimitNumToDisplay)
 
 	// Domain Layer Instances (Use Cases)
 	slog.Info("Initializing Domain...")
-	// Use case for today's programs
-	annictInfo := usecase.NewAnnictInfoGetter(annictRepo, httpValidator)
+	// Use case for linking Slack users to their Annict accounts
+	accountLinker := usecase.NewAccountLinker(tokenStore, accountVerifier, oauthProvider, oauthStates)
+	// Use case for the streaming services each Slack user subscribes to
+	streamingSubscriptions := usecase.NewStreamingSubscriptions(streamingProvider, store)
+	// Use case for the broadcast channels each Slack user wants to see
+	channelPreferences := usecase.NewChannelPreferenceSettings(store)
+	// Use case for updating each channel's daily digest in place during the day
+	dailyDigests := usecase.NewDailyDigestLog(store)
+	// Use cases bound to one Annict account, built per request from the caller's token
+	newProgramRepo := func(token string, annictClient *annict.Client) usecase.ProgramRepository {
+		annictRepo := repository.NewAnnictRepository(annictClient, logger, pagination)
+		if programCache == nil {
+			return annictRepo
+		}
+		return programCache.Programs(repository.CacheNamespace(token), annictRepo)
+	}
+	newUserServices := func(token string) *slack.UserServices {
+		annictClient := newAnnictClient(token)
+		annictRepo := newProgramRepo(token, annictClient)
+		recordRepo := repository.NewAnnictRecordRepository(annictClient, logger)
+		statusRepo := repository.NewAnnictStatusRepository(annictClient, logger)
+		searchRepo := repository.NewAnnictWorkSearchRepository(annictClient, logger)
+		previewRepo := repository.NewAnnictSeasonPreviewRepository(annictClient, logger, pagination)
+		statsRepo := repository.NewAnnictWatchStatsRepository(annictClient, logger)
+		if programCache != nil {
+			recordRepo = programCache.Records(repository.CacheNamespace(token), recordRepo)
+			statusRepo = programCache.Statuses(repository.CacheNamespace(token), statusRepo)
+		}
+		return &slack.UserServices{
+			AnnictInfoGetter: usecase.NewAnnictInfoGetter(annictRepo, imageFallback, cfg.ImageCheckConcurrency, streamingProvider),
+			WeeklySchedule:   usecase.NewWeeklyScheduleGetter(annictRepo),
+			EpisodeRecorder:  usecase.NewEpisodeRecorder(recordRepo),
+			StatusUpdater:    usecase.NewStatusUpdater(statusRepo),
+			Channels:         usecase.NewChannelLister(annictRepo, store, repository.CacheNamespace(token)),
+			WorkSearcher:     usecase.NewWorkSearcher(searchRepo, imageFallback, cfg.ImageCheckConcurrency),
+			SeasonLineup:     usecase.NewSeasonLineup(searchRepo),
+			SeasonPreview:    usecase.NewSeasonPreview(previewRepo, store, cfg.SeasonPreviewLeadDays),
+			WatchStats:       usecase.NewWatchStatsGetter(statsRepo),
+		}
+	}
+	// The bot owner's account (ANNICT_ACCESS_TOKEN) backs scheduled posts and reminders
+	defaultAnnictRepo := newProgramRepo(cfg.AnnictToken, newAnnictClient(cfg.AnnictToken))
 
 	// Slack Bot (Infrastructure, orchestrates everything)
 	slog.Info("Initializing Slack Bot...")
 	slackBot, err := slack.NewBot(
 		cfg.SlackBotToken,
 		cfg.SlackAppToken,
-		annictInfo,
+		accountLinker,
+		streamingSubscriptions,
+		channelPreferences,
+		dailyDigests,
+		newUserServices,
+		newUserServices(cfg.AnnictToken),
 		slackPresenter,
 		cfg.IsDevelopment,
 	)
@@ -85,10 +266,77 @@ func main() {
 		slog.Error(fmt.Sprintf("Error creating Slack bot: %s", err.Error()))
 	}
 
+	// Scheduler (runs jobs such as the daily digest inside the bot process)
+	slog.Info("Initializing Scheduler...")
+	jobScheduler := scheduler.New(store, cfg.ScheduleCatchUpWindow)
+	if cfg.ScheduleChannelID != "" {
+		err = jobScheduler.Add(scheduler.Job{
+			Name: "daily_digest",
+			Spec: cfg.ScheduleDailyDigestCron,
+			Run: func(ctx context.Context) error {
+				return slackBot.PostDailyDigest(ctx, cfg.ScheduleChannelID)
+			},
+		})
+		if err != nil {
+			log.Fatalf("FATAL: Error scheduling daily digest: %v", err)
+		}
+		// Checked daily; posts only SEASON_PREVIEW_LEAD_DAYS before the season changes
+		err = jobScheduler.Add(scheduler.Job{
+			Name: "season_preview",
+			Spec: cfg.SeasonPreviewCron,
+			Run: func(ctx context.Context) error {
+				return slackBot.PostSeasonPreview(ctx, cfg.ScheduleChannelID)
+			},
+		})
+		if err != nil {
+			log.Fatalf("FATAL: Error scheduling season preview: %v", err)
+		}
+	}
+
+	// Broadcast reminders (posted a few minutes before each program starts)
+	var broadcastReminder *usecase.BroadcastReminder
+	if cfg.ReminderChannelID != "" && cfg.ReminderLeadTime > 0 {
+		broadcastReminder = usecase.NewBroadcastReminder(
+			defaultAnnictRepo,
+			slackBot.NewChannelNotifier(cfg.ReminderChannelID),
+			store,
+			cfg.ReminderLeadTime,
+			cfg.ReminderSyncInterval,
+		)
+	}
+
+	// HTTP server for the OAuth callback (redirect after a user authorizes the bot on Annict)
+	// and the image proxy; it only runs when one of them is configured.
+	var httpServer *httpserver.Server
+	if cfg.OAuthEnabled() || imageProxy != nil {
+		httpServer = httpserver.New(cfg.HTTPServerAddr)
+		if cfg.OAuthEnabled() {
+			httpServer.Handle(oauth.CallbackPath, oauth.NewCallbackHandler(accountLinker, slackBot))
+		}
+		if imageProxy != nil {
+			proxyHandler := imageProxy.Handler()
+			httpServer.Handle(imageproxy.ThumbnailPath, proxyHandler)
+			httpServer.Handle(imageproxy.PlaceholderPath, proxyHandler)
+		}
+	}
+
 	// Graceful Shutdown Setup
 	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
 	defer stop()
 
+	// Start the Scheduler
+	go jobScheduler.Run(ctx)
+	if broadcastReminder != nil {
+		go broadcastReminder.Run(ctx)
+	}
+	if httpServer != nil {
+		go func() {
+			if err := httpServer.Run(ctx); err != nil {
+				slog.Error(fmt.Sprintf("HTTP server stopped with error: %s", err.Error()))
+			}
+		}()
+	}
+
 	// Start the Bot
 	slog.Info("Starting bot...")
 	err = slackBot.Run(ctx)
@@ -0,0 +1,49 @@
+package entity
+
+import "strings"
+
+// ChannelPreferences holds which broadcast channels a user wants to see.
+type ChannelPreferences struct {
+	Allowed  []Channel // Only programs on these channels are shown; empty allows every channel
+	Priority []Channel // Most preferred first, used when an episode airs on several channels
+}
+
+// IsEmpty reports whether no preference is set.
+func (cp *ChannelPreferences) IsEmpty() bool {
+	return cp == nil || (len(cp.Allowed) == 0 && len(cp.Priority) == 0)
+}
+
+// Allows reports whether programs on the channel should be shown.
+func (cp *ChannelPreferences) Allows(ch Channel) bool {
+	if cp == nil || len(cp.Allowed) == 0 {
+		return true
+	}
+	for _, allowed := range cp.Allowed {
+		if allowed.Matches(ch) {
+			return true
+		}
+	}
+	return false
+}
+
+// Rank returns the channel's position in the priority order; unlisted channels rank last.
+func (cp *ChannelPreferences) Rank(ch Channel) int {
+	if cp == nil {
+		return 0
+	}
+	for i, preferred := range cp.Priority {
+		if preferred.Matches(ch) {
+			return i
+		}
+	}
+	return len(cp.Priority)
+}
+
+// Matches reports whether both values denote the same channel: by Annict ID when
+// both have one, by name otherwise.
+func (c Channel) Matches(other Channel) bool {
+	if c.AnnictID != 0 && other.AnnictID != 0 {
+		return c.AnnictID == other.AnnictID
+	}
+	return strings.EqualFold(strings.TrimSpace(c.Name), strings.TrimSpace(other.Name))
+}
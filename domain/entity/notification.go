@@ -0,0 +1,25 @@
+package entity
+
+import "time"
+
+// NotificationKind identifies what kind of message the bot sent.
+type NotificationKind string
+
+const (
+	NotificationKindReminder    NotificationKind = "reminder"
+	NotificationKindDailyDigest NotificationKind = "daily_digest"
+	// NotificationKindDailyDigestExpanded marks a daily digest whose "もっと見る" button was clicked.
+	NotificationKindDailyDigestExpanded NotificationKind = "daily_digest_expanded"
+	// NotificationKindSeasonPreview is keyed by the Annict season, e.g. "2027-winter".
+	NotificationKindSeasonPreview NotificationKind = "season_preview"
+)
+
+// Notification is an entry of the sent-message log.
+// Key identifies the subject (e.g. a program ID) and is unique per kind.
+type Notification struct {
+	Kind      NotificationKind
+	Key       string
+	ChannelID string
+	MessageTS string
+	SentAt    time.Time
+}
@@ -4,27 +4,60 @@ import "time"
 
 // Work represents an anime work.
 type Work struct {
+	ID              string // Annict GraphQL node ID, used for status updates
+	AnnictID        int64  // Numeric Annict work ID, 0 when unknown
+	SyobocalTID     int64  // Syobocal title ID, 0 when unknown
 	Title           string
-	OfficialSiteURL *string // Nullable
-	ImageURL        *string // Nullable and validated
+	OfficialSiteURL *string     // Nullable
+	ImageURL        *string     // Nullable and validated
+	ImageCandidates []string    // Image URLs from Annict in order of preference, before validation
+	Status          WatchStatus // Viewer's status, empty when unknown
+	Streaming       []StreamingService
+
+	// Catalog details, only filled in by work searches.
+	Season           string   // Annict season, e.g. "2026-autumn"; empty when unknown
+	Media            string   // "TV", "OVA", "MOVIE", "WEB" or "OTHER"
+	EpisodesCount    int      // Number of episodes registered on Annict
+	SatisfactionRate *float64 // Percentage of positive ratings, nil when there are too few
+	WatchersCount    int      // Number of users who have the work in their library
 }
 
 // Episode represents an anime episode.
 type Episode struct {
-	NumberText string // Formatted number, e.g., "第1話"
+	ID         string  // Annict GraphQL node ID, used for recording
+	NumberText string  // Formatted number, e.g., "第1話"
 	Title      *string // Nullable
 }
 
 // Channel represents a broadcast channel.
 type Channel struct {
-	Name string
+	AnnictID  int64 // Numeric Annict channel ID, 0 when unknown
+	Name      string
+	GroupName string // Annict channel group, e.g. "地上波" or "BS"
 }
 
+// ProgramState represents whether a program is listed on Annict.
+type ProgramState string
+
+const (
+	ProgramStatePublished ProgramState = "PUBLISHED"
+	// ProgramStateHidden is used for programs taken off the schedule, e.g. cancelled broadcasts.
+	ProgramStateHidden ProgramState = "HIDDEN"
+)
+
 // Program represents a scheduled broadcast of an episode.
 // This is the core entity for our use case.
 type Program struct {
-	Work      Work
-	Episode   Episode
-	Channel   Channel
-	StartTime time.Time // Always in JST
+	ID          string // Annict GraphQL node ID, empty for library entries
+	Work        Work
+	Episode     Episode
+	Channel     Channel
+	StartTime   time.Time    // Always in JST
+	Rebroadcast bool         // The program repeats an earlier broadcast
+	State       ProgramState // Empty when unknown, e.g. for library entries
+}
+
+// IsHidden reports whether the program was taken off the schedule.
+func (p *Program) IsHidden() bool {
+	return p.State == ProgramStateHidden
 }
@@ -0,0 +1,36 @@
+package entity
+
+import (
+	"fmt"
+	"strings"
+)
+
+// WatchStatus represents the viewer's watch status of a work.
+type WatchStatus string
+
+const (
+	WatchStatusWatching     WatchStatus = "WATCHING"
+	WatchStatusWannaWatch   WatchStatus = "WANNA_WATCH"
+	WatchStatusOnHold       WatchStatus = "ON_HOLD"
+	WatchStatusStopWatching WatchStatus = "STOP_WATCHING"
+	WatchStatusWatched      WatchStatus = "WATCHED"
+)
+
+// ChangeableWatchStatuses lists the statuses a user can switch a work to from Slack.
+var ChangeableWatchStatuses = []WatchStatus{
+	WatchStatusWatching,
+	WatchStatusWannaWatch,
+	WatchStatusOnHold,
+	WatchStatusStopWatching,
+}
+
+// ParseWatchStatus parses user input such as "watching", "wanna-watch" or "ON_HOLD".
+func ParseWatchStatus(s string) (WatchStatus, error) {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(s), "-", "_"))
+	for _, status := range ChangeableWatchStatuses {
+		if string(status) == normalized {
+			return status, nil
+		}
+	}
+	return "", fmt.Errorf("unknown watch status: %q", s)
+}
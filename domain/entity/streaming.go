@@ -0,0 +1,7 @@
+package entity
+
+// StreamingService is a service on which a work can be watched online.
+type StreamingService struct {
+	Name string // Display name, e.g. "dアニメストア"
+	URL  string // Page of the work on the service
+}
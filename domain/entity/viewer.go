@@ -0,0 +1,18 @@
+package entity
+
+// Viewer represents the Annict account an access token belongs to.
+type Viewer struct {
+	Username string
+	Name     string
+}
+
+// WatchStats holds the number of works in each status of a viewer's library
+// and how many records the viewer has written.
+type WatchStats struct {
+	Watching     int
+	WannaWatch   int
+	Watched      int
+	OnHold       int
+	StopWatching int
+	Records      int
+}
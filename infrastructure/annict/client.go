@@ -16,6 +16,53 @@ func NewClient(cli clientv2.HttpClient, baseURL string, options *clientv2.Option
 	return &Client{Client: clientv2.NewClient(cli, baseURL, options, interceptors...)}
 }
 
+type CreateRecord_CreateRecord_Record_Episode struct {
+	ID             string "json:\"id\" graphql:\"id\""
+	ViewerDidTrack bool   "json:\"viewerDidTrack\" graphql:\"viewerDidTrack\""
+}
+
+func (t *CreateRecord_CreateRecord_Record_Episode) GetID() string {
+	if t == nil {
+		t = &CreateRecord_CreateRecord_Record_Episode{}
+	}
+	return t.ID
+}
+func (t *CreateRecord_CreateRecord_Record_Episode) GetViewerDidTrack() bool {
+	if t == nil {
+		t = &CreateRecord_CreateRecord_Record_Episode{}
+	}
+	return t.ViewerDidTrack
+}
+
+type CreateRecord_CreateRecord_Record struct {
+	Episode CreateRecord_CreateRecord_Record_Episode "json:\"episode\" graphql:\"episode\""
+	ID      string                                   "json:\"id\" graphql:\"id\""
+}
+
+func (t *CreateRecord_CreateRecord_Record) GetEpisode() *CreateRecord_CreateRecord_Record_Episode {
+	if t == nil {
+		t = &CreateRecord_CreateRecord_Record{}
+	}
+	return &t.Episode
+}
+func (t *CreateRecord_CreateRecord_Record) GetID() string {
+	if t == nil {
+		t = &CreateRecord_CreateRecord_Record{}
+	}
+	return t.ID
+}
+
+type CreateRecord_CreateRecord struct {
+	Record *CreateRecord_CreateRecord_Record "json:\"record,omitempty\" graphql:\"record\""
+}
+
+func (t *CreateRecord_CreateRecord) GetRecord() *CreateRecord_CreateRecord_Record {
+	if t == nil {
+		t = &CreateRecord_CreateRecord{}
+	}
+	return t.Record
+}
+
 type GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work_Image struct {
 	FacebookOgImageURL  *string "json:\"facebookOgImageUrl,omitempty\" graphql:\"facebookOgImageUrl\""
 	RecommendedImageURL *string "json:\"recommendedImageUrl,omitempty\" graphql:\"recommendedImageUrl\""
@@ -35,11 +82,27 @@ func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work_Image) GetRecommende
 }
 
 type GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work struct {
-	Image           *GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work_Image "json:\"image,omitempty\" graphql:\"image\""
-	OfficialSiteURL *string                                                   "json:\"officialSiteUrl,omitempty\" graphql:\"officialSiteUrl\""
-	Title           string                                                    "json:\"title\" graphql:\"title\""
+	AnnictID          int64                                                     "json:\"annictId\" graphql:\"annictId\""
+	ID                string                                                    "json:\"id\" graphql:\"id\""
+	Image             *GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work_Image "json:\"image,omitempty\" graphql:\"image\""
+	OfficialSiteURL   *string                                                   "json:\"officialSiteUrl,omitempty\" graphql:\"officialSiteUrl\""
+	SyobocalTid       *int64                                                    "json:\"syobocalTid,omitempty\" graphql:\"syobocalTid\""
+	Title             string                                                    "json:\"title\" graphql:\"title\""
+	ViewerStatusState *StatusState                                              "json:\"viewerStatusState,omitempty\" graphql:\"viewerStatusState\""
 }
 
+func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work) GetAnnictID() int64 {
+	if t == nil {
+		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work{}
+	}
+	return t.AnnictID
+}
+func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work) GetID() string {
+	if t == nil {
+		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work{}
+	}
+	return t.ID
+}
 func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work) GetImage() *GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work_Image {
 	if t == nil {
 		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work{}
@@ -52,17 +115,54 @@ func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work) GetOfficialSiteURL(
 	}
 	return t.OfficialSiteURL
 }
+func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work) GetSyobocalTid() *int64 {
+	if t == nil {
+		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work{}
+	}
+	return t.SyobocalTid
+}
 func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work) GetTitle() string {
 	if t == nil {
 		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work{}
 	}
 	return t.Title
 }
+func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work) GetViewerStatusState() *StatusState {
+	if t == nil {
+		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_Work{}
+	}
+	return t.ViewerStatusState
+}
 
-type GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel struct {
+type GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel_ChannelGroup struct {
 	Name string "json:\"name\" graphql:\"name\""
 }
 
+func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel_ChannelGroup) GetName() string {
+	if t == nil {
+		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel_ChannelGroup{}
+	}
+	return t.Name
+}
+
+type GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel struct {
+	AnnictID     int64                                                                          "json:\"annictId\" graphql:\"annictId\""
+	ChannelGroup GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel_ChannelGroup "json:\"channelGroup\" graphql:\"channelGroup\""
+	Name         string                                                                         "json:\"name\" graphql:\"name\""
+}
+
+func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel) GetAnnictID() int64 {
+	if t == nil {
+		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel{}
+	}
+	return t.AnnictID
+}
+func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel) GetChannelGroup() *GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel_ChannelGroup {
+	if t == nil {
+		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel{}
+	}
+	return &t.ChannelGroup
+}
 func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel) GetName() string {
 	if t == nil {
 		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram_Channel{}
@@ -89,10 +189,17 @@ func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextProgram) GetStartedAt
 }
 
 type GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextEpisode struct {
+	ID         string  "json:\"id\" graphql:\"id\""
 	NumberText *string "json:\"numberText,omitempty\" graphql:\"numberText\""
 	Title      *string "json:\"title,omitempty\" graphql:\"title\""
 }
 
+func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextEpisode) GetID() string {
+	if t == nil {
+		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextEpisode{}
+	}
+	return t.ID
+}
 func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextEpisode) GetNumberText() *string {
 	if t == nil {
 		t = &GetLibraryEntries_Viewer_LibraryEntries_Nodes_NextEpisode{}
@@ -131,8 +238,27 @@ func (t *GetLibraryEntries_Viewer_LibraryEntries_Nodes) GetWork() *GetLibraryEnt
 	return &t.Work
 }
 
+type GetLibraryEntries_Viewer_LibraryEntries_PageInfo struct {
+	EndCursor   *string "json:\"endCursor,omitempty\" graphql:\"endCursor\""
+	HasNextPage bool    "json:\"hasNextPage\" graphql:\"hasNextPage\""
+}
+
+func (t *GetLibraryEntries_Viewer_LibraryEntries_PageInfo) GetEndCursor() *string {
+	if t == nil {
+		t = &GetLibraryEntries_Viewer_LibraryEntries_PageInfo{}
+	}
+	return t.EndCursor
+}
+func (t *GetLibraryEntries_Viewer_LibraryEntries_PageInfo) GetHasNextPage() bool {
+	if t == nil {
+		t = &GetLibraryEntries_Viewer_LibraryEntries_PageInfo{}
+	}
+	return t.HasNextPage
+}
+
 type GetLibraryEntries_Viewer_LibraryEntries struct {
-	Nodes []*GetLibraryEntries_Viewer_LibraryEntries_Nodes "json:\"nodes,omitempty\" graphql:\"nodes\""
+	Nodes    []*GetLibraryEntries_Viewer_LibraryEntries_Nodes "json:\"nodes,omitempty\" graphql:\"nodes\""
+	PageInfo GetLibraryEntries_Viewer_LibraryEntries_PageInfo "json:\"pageInfo\" graphql:\"pageInfo\""
 }
 
 func (t *GetLibraryEntries_Viewer_LibraryEntries) GetNodes() []*GetLibraryEntries_Viewer_LibraryEntries_Nodes {
@@ -141,6 +267,12 @@ func (t *GetLibraryEntries_Viewer_LibraryEntries) GetNodes() []*GetLibraryEntrie
 	}
 	return t.Nodes
 }
+func (t *GetLibraryEntries_Viewer_LibraryEntries) GetPageInfo() *GetLibraryEntries_Viewer_LibraryEntries_PageInfo {
+	if t == nil {
+		t = &GetLibraryEntries_Viewer_LibraryEntries{}
+	}
+	return &t.PageInfo
+}
 
 type GetLibraryEntries_Viewer struct {
 	LibraryEntries *GetLibraryEntries_Viewer_LibraryEntries "json:\"libraryEntries,omitempty\" graphql:\"libraryEntries\""
@@ -153,6 +285,89 @@ func (t *GetLibraryEntries_Viewer) GetLibraryEntries() *GetLibraryEntries_Viewer
 	return t.LibraryEntries
 }
 
+type GetLibraryWorks_Viewer_LibraryEntries_Nodes_Work struct {
+	ID                string       "json:\"id\" graphql:\"id\""
+	Title             string       "json:\"title\" graphql:\"title\""
+	ViewerStatusState *StatusState "json:\"viewerStatusState,omitempty\" graphql:\"viewerStatusState\""
+}
+
+func (t *GetLibraryWorks_Viewer_LibraryEntries_Nodes_Work) GetID() string {
+	if t == nil {
+		t = &GetLibraryWorks_Viewer_LibraryEntries_Nodes_Work{}
+	}
+	return t.ID
+}
+func (t *GetLibraryWorks_Viewer_LibraryEntries_Nodes_Work) GetTitle() string {
+	if t == nil {
+		t = &GetLibraryWorks_Viewer_LibraryEntries_Nodes_Work{}
+	}
+	return t.Title
+}
+func (t *GetLibraryWorks_Viewer_LibraryEntries_Nodes_Work) GetViewerStatusState() *StatusState {
+	if t == nil {
+		t = &GetLibraryWorks_Viewer_LibraryEntries_Nodes_Work{}
+	}
+	return t.ViewerStatusState
+}
+
+type GetLibraryWorks_Viewer_LibraryEntries_Nodes struct {
+	Work GetLibraryWorks_Viewer_LibraryEntries_Nodes_Work "json:\"work\" graphql:\"work\""
+}
+
+func (t *GetLibraryWorks_Viewer_LibraryEntries_Nodes) GetWork() *GetLibraryWorks_Viewer_LibraryEntries_Nodes_Work {
+	if t == nil {
+		t = &GetLibraryWorks_Viewer_LibraryEntries_Nodes{}
+	}
+	return &t.Work
+}
+
+type GetLibraryWorks_Viewer_LibraryEntries_PageInfo struct {
+	EndCursor   *string "json:\"endCursor,omitempty\" graphql:\"endCursor\""
+	HasNextPage bool    "json:\"hasNextPage\" graphql:\"hasNextPage\""
+}
+
+func (t *GetLibraryWorks_Viewer_LibraryEntries_PageInfo) GetEndCursor() *string {
+	if t == nil {
+		t = &GetLibraryWorks_Viewer_LibraryEntries_PageInfo{}
+	}
+	return t.EndCursor
+}
+func (t *GetLibraryWorks_Viewer_LibraryEntries_PageInfo) GetHasNextPage() bool {
+	if t == nil {
+		t = &GetLibraryWorks_Viewer_LibraryEntries_PageInfo{}
+	}
+	return t.HasNextPage
+}
+
+type GetLibraryWorks_Viewer_LibraryEntries struct {
+	Nodes    []*GetLibraryWorks_Viewer_LibraryEntries_Nodes "json:\"nodes,omitempty\" graphql:\"nodes\""
+	PageInfo GetLibraryWorks_Viewer_LibraryEntries_PageInfo "json:\"pageInfo\" graphql:\"pageInfo\""
+}
+
+func (t *GetLibraryWorks_Viewer_LibraryEntries) GetNodes() []*GetLibraryWorks_Viewer_LibraryEntries_Nodes {
+	if t == nil {
+		t = &GetLibraryWorks_Viewer_LibraryEntries{}
+	}
+	return t.Nodes
+}
+func (t *GetLibraryWorks_Viewer_LibraryEntries) GetPageInfo() *GetLibraryWorks_Viewer_LibraryEntries_PageInfo {
+	if t == nil {
+		t = &GetLibraryWorks_Viewer_LibraryEntries{}
+	}
+	return &t.PageInfo
+}
+
+type GetLibraryWorks_Viewer struct {
+	LibraryEntries *GetLibraryWorks_Viewer_LibraryEntries "json:\"libraryEntries,omitempty\" graphql:\"libraryEntries\""
+}
+
+func (t *GetLibraryWorks_Viewer) GetLibraryEntries() *GetLibraryWorks_Viewer_LibraryEntries {
+	if t == nil {
+		t = &GetLibraryWorks_Viewer{}
+	}
+	return t.LibraryEntries
+}
+
 type GetPrograms_Viewer_Programs_Nodes_Work_Image struct {
 	FacebookOgImageURL  *string "json:\"facebookOgImageUrl,omitempty\" graphql:\"facebookOgImageUrl\""
 	RecommendedImageURL *string "json:\"recommendedImageUrl,omitempty\" graphql:\"recommendedImageUrl\""
@@ -172,11 +387,19 @@ func (t *GetPrograms_Viewer_Programs_Nodes_Work_Image) GetRecommendedImageURL()
 }
 
 type GetPrograms_Viewer_Programs_Nodes_Work struct {
+	AnnictID        int64                                         "json:\"annictId\" graphql:\"annictId\""
 	Image           *GetPrograms_Viewer_Programs_Nodes_Work_Image "json:\"image,omitempty\" graphql:\"image\""
 	OfficialSiteURL *string                                       "json:\"officialSiteUrl,omitempty\" graphql:\"officialSiteUrl\""
+	SyobocalTid     *int64                                        "json:\"syobocalTid,omitempty\" graphql:\"syobocalTid\""
 	Title           string                                        "json:\"title\" graphql:\"title\""
 }
 
+func (t *GetPrograms_Viewer_Programs_Nodes_Work) GetAnnictID() int64 {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs_Nodes_Work{}
+	}
+	return t.AnnictID
+}
 func (t *GetPrograms_Viewer_Programs_Nodes_Work) GetImage() *GetPrograms_Viewer_Programs_Nodes_Work_Image {
 	if t == nil {
 		t = &GetPrograms_Viewer_Programs_Nodes_Work{}
@@ -189,6 +412,12 @@ func (t *GetPrograms_Viewer_Programs_Nodes_Work) GetOfficialSiteURL() *string {
 	}
 	return t.OfficialSiteURL
 }
+func (t *GetPrograms_Viewer_Programs_Nodes_Work) GetSyobocalTid() *int64 {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs_Nodes_Work{}
+	}
+	return t.SyobocalTid
+}
 func (t *GetPrograms_Viewer_Programs_Nodes_Work) GetTitle() string {
 	if t == nil {
 		t = &GetPrograms_Viewer_Programs_Nodes_Work{}
@@ -196,10 +425,35 @@ func (t *GetPrograms_Viewer_Programs_Nodes_Work) GetTitle() string {
 	return t.Title
 }
 
-type GetPrograms_Viewer_Programs_Nodes_Channel struct {
+type GetPrograms_Viewer_Programs_Nodes_Channel_ChannelGroup struct {
 	Name string "json:\"name\" graphql:\"name\""
 }
 
+func (t *GetPrograms_Viewer_Programs_Nodes_Channel_ChannelGroup) GetName() string {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs_Nodes_Channel_ChannelGroup{}
+	}
+	return t.Name
+}
+
+type GetPrograms_Viewer_Programs_Nodes_Channel struct {
+	AnnictID     int64                                                  "json:\"annictId\" graphql:\"annictId\""
+	ChannelGroup GetPrograms_Viewer_Programs_Nodes_Channel_ChannelGroup "json:\"channelGroup\" graphql:\"channelGroup\""
+	Name         string                                                 "json:\"name\" graphql:\"name\""
+}
+
+func (t *GetPrograms_Viewer_Programs_Nodes_Channel) GetAnnictID() int64 {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs_Nodes_Channel{}
+	}
+	return t.AnnictID
+}
+func (t *GetPrograms_Viewer_Programs_Nodes_Channel) GetChannelGroup() *GetPrograms_Viewer_Programs_Nodes_Channel_ChannelGroup {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs_Nodes_Channel{}
+	}
+	return &t.ChannelGroup
+}
 func (t *GetPrograms_Viewer_Programs_Nodes_Channel) GetName() string {
 	if t == nil {
 		t = &GetPrograms_Viewer_Programs_Nodes_Channel{}
@@ -208,11 +462,18 @@ func (t *GetPrograms_Viewer_Programs_Nodes_Channel) GetName() string {
 }
 
 type GetPrograms_Viewer_Programs_Nodes_Episode struct {
+	ID         string  "json:\"id\" graphql:\"id\""
 	Number     *int64  "json:\"number,omitempty\" graphql:\"number\""
 	NumberText *string "json:\"numberText,omitempty\" graphql:\"numberText\""
 	Title      *string "json:\"title,omitempty\" graphql:\"title\""
 }
 
+func (t *GetPrograms_Viewer_Programs_Nodes_Episode) GetID() string {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs_Nodes_Episode{}
+	}
+	return t.ID
+}
 func (t *GetPrograms_Viewer_Programs_Nodes_Episode) GetNumber() *int64 {
 	if t == nil {
 		t = &GetPrograms_Viewer_Programs_Nodes_Episode{}
@@ -233,10 +494,13 @@ func (t *GetPrograms_Viewer_Programs_Nodes_Episode) GetTitle() *string {
 }
 
 type GetPrograms_Viewer_Programs_Nodes struct {
-	Channel   GetPrograms_Viewer_Programs_Nodes_Channel "json:\"channel\" graphql:\"channel\""
-	Episode   GetPrograms_Viewer_Programs_Nodes_Episode "json:\"episode\" graphql:\"episode\""
-	StartedAt string                                    "json:\"startedAt\" graphql:\"startedAt\""
-	Work      GetPrograms_Viewer_Programs_Nodes_Work    "json:\"work\" graphql:\"work\""
+	Channel     GetPrograms_Viewer_Programs_Nodes_Channel "json:\"channel\" graphql:\"channel\""
+	Episode     GetPrograms_Viewer_Programs_Nodes_Episode "json:\"episode\" graphql:\"episode\""
+	ID          string                                    "json:\"id\" graphql:\"id\""
+	Rebroadcast bool                                      "json:\"rebroadcast\" graphql:\"rebroadcast\""
+	StartedAt   string                                    "json:\"startedAt\" graphql:\"startedAt\""
+	State       ProgramState                              "json:\"state\" graphql:\"state\""
+	Work        GetPrograms_Viewer_Programs_Nodes_Work    "json:\"work\" graphql:\"work\""
 }
 
 func (t *GetPrograms_Viewer_Programs_Nodes) GetChannel() *GetPrograms_Viewer_Programs_Nodes_Channel {
@@ -251,12 +515,30 @@ func (t *GetPrograms_Viewer_Programs_Nodes) GetEpisode() *GetPrograms_Viewer_Pro
 	}
 	return &t.Episode
 }
+func (t *GetPrograms_Viewer_Programs_Nodes) GetID() string {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs_Nodes{}
+	}
+	return t.ID
+}
+func (t *GetPrograms_Viewer_Programs_Nodes) GetRebroadcast() bool {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs_Nodes{}
+	}
+	return t.Rebroadcast
+}
 func (t *GetPrograms_Viewer_Programs_Nodes) GetStartedAt() string {
 	if t == nil {
 		t = &GetPrograms_Viewer_Programs_Nodes{}
 	}
 	return t.StartedAt
 }
+func (t *GetPrograms_Viewer_Programs_Nodes) GetState() *ProgramState {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs_Nodes{}
+	}
+	return &t.State
+}
 func (t *GetPrograms_Viewer_Programs_Nodes) GetWork() *GetPrograms_Viewer_Programs_Nodes_Work {
 	if t == nil {
 		t = &GetPrograms_Viewer_Programs_Nodes{}
@@ -264,8 +546,27 @@ func (t *GetPrograms_Viewer_Programs_Nodes) GetWork() *GetPrograms_Viewer_Progra
 	return &t.Work
 }
 
+type GetPrograms_Viewer_Programs_PageInfo struct {
+	EndCursor   *string "json:\"endCursor,omitempty\" graphql:\"endCursor\""
+	HasNextPage bool    "json:\"hasNextPage\" graphql:\"hasNextPage\""
+}
+
+func (t *GetPrograms_Viewer_Programs_PageInfo) GetEndCursor() *string {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs_PageInfo{}
+	}
+	return t.EndCursor
+}
+func (t *GetPrograms_Viewer_Programs_PageInfo) GetHasNextPage() bool {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs_PageInfo{}
+	}
+	return t.HasNextPage
+}
+
 type GetPrograms_Viewer_Programs struct {
-	Nodes []*GetPrograms_Viewer_Programs_Nodes "json:\"nodes,omitempty\" graphql:\"nodes\""
+	Nodes    []*GetPrograms_Viewer_Programs_Nodes "json:\"nodes,omitempty\" graphql:\"nodes\""
+	PageInfo GetPrograms_Viewer_Programs_PageInfo "json:\"pageInfo\" graphql:\"pageInfo\""
 }
 
 func (t *GetPrograms_Viewer_Programs) GetNodes() []*GetPrograms_Viewer_Programs_Nodes {
@@ -274,6 +575,12 @@ func (t *GetPrograms_Viewer_Programs) GetNodes() []*GetPrograms_Viewer_Programs_
 	}
 	return t.Nodes
 }
+func (t *GetPrograms_Viewer_Programs) GetPageInfo() *GetPrograms_Viewer_Programs_PageInfo {
+	if t == nil {
+		t = &GetPrograms_Viewer_Programs{}
+	}
+	return &t.PageInfo
+}
 
 type GetPrograms_Viewer struct {
 	Programs *GetPrograms_Viewer_Programs "json:\"programs,omitempty\" graphql:\"programs\""
@@ -286,6 +593,262 @@ func (t *GetPrograms_Viewer) GetPrograms() *GetPrograms_Viewer_Programs {
 	return t.Programs
 }
 
+type GetViewer_Viewer struct {
+	Username          string "json:\"username\" graphql:\"username\""
+	Name              string "json:\"name\" graphql:\"name\""
+	WatchingCount     int64  "json:\"watchingCount\" graphql:\"watchingCount\""
+	WannaWatchCount   int64  "json:\"wannaWatchCount\" graphql:\"wannaWatchCount\""
+	WatchedCount      int64  "json:\"watchedCount\" graphql:\"watchedCount\""
+	OnHoldCount       int64  "json:\"onHoldCount\" graphql:\"onHoldCount\""
+	StopWatchingCount int64  "json:\"stopWatchingCount\" graphql:\"stopWatchingCount\""
+	RecordsCount      int64  "json:\"recordsCount\" graphql:\"recordsCount\""
+}
+
+func (t *GetViewer_Viewer) GetName() string {
+	if t == nil {
+		t = &GetViewer_Viewer{}
+	}
+	return t.Name
+}
+func (t *GetViewer_Viewer) GetOnHoldCount() int64 {
+	if t == nil {
+		t = &GetViewer_Viewer{}
+	}
+	return t.OnHoldCount
+}
+func (t *GetViewer_Viewer) GetRecordsCount() int64 {
+	if t == nil {
+		t = &GetViewer_Viewer{}
+	}
+	return t.RecordsCount
+}
+func (t *GetViewer_Viewer) GetStopWatchingCount() int64 {
+	if t == nil {
+		t = &GetViewer_Viewer{}
+	}
+	return t.StopWatchingCount
+}
+func (t *GetViewer_Viewer) GetUsername() string {
+	if t == nil {
+		t = &GetViewer_Viewer{}
+	}
+	return t.Username
+}
+func (t *GetViewer_Viewer) GetWannaWatchCount() int64 {
+	if t == nil {
+		t = &GetViewer_Viewer{}
+	}
+	return t.WannaWatchCount
+}
+func (t *GetViewer_Viewer) GetWatchedCount() int64 {
+	if t == nil {
+		t = &GetViewer_Viewer{}
+	}
+	return t.WatchedCount
+}
+func (t *GetViewer_Viewer) GetWatchingCount() int64 {
+	if t == nil {
+		t = &GetViewer_Viewer{}
+	}
+	return t.WatchingCount
+}
+
+type SearchWorks_SearchWorks_Nodes_Image struct {
+	FacebookOgImageURL  *string "json:\"facebookOgImageUrl,omitempty\" graphql:\"facebookOgImageUrl\""
+	RecommendedImageURL *string "json:\"recommendedImageUrl,omitempty\" graphql:\"recommendedImageUrl\""
+}
+
+func (t *SearchWorks_SearchWorks_Nodes_Image) GetFacebookOgImageURL() *string {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes_Image{}
+	}
+	return t.FacebookOgImageURL
+}
+func (t *SearchWorks_SearchWorks_Nodes_Image) GetRecommendedImageURL() *string {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes_Image{}
+	}
+	return t.RecommendedImageURL
+}
+
+type SearchWorks_SearchWorks_Nodes struct {
+	AnnictID          int64                                "json:\"annictId\" graphql:\"annictId\""
+	EpisodesCount     int64                                "json:\"episodesCount\" graphql:\"episodesCount\""
+	ID                string                               "json:\"id\" graphql:\"id\""
+	Image             *SearchWorks_SearchWorks_Nodes_Image "json:\"image,omitempty\" graphql:\"image\""
+	Media             Media                                "json:\"media\" graphql:\"media\""
+	OfficialSiteURL   *string                              "json:\"officialSiteUrl,omitempty\" graphql:\"officialSiteUrl\""
+	SatisfactionRate  *float64                             "json:\"satisfactionRate,omitempty\" graphql:\"satisfactionRate\""
+	SeasonName        *SeasonName                          "json:\"seasonName,omitempty\" graphql:\"seasonName\""
+	SeasonYear        *int64                               "json:\"seasonYear,omitempty\" graphql:\"seasonYear\""
+	SyobocalTid       *int64                               "json:\"syobocalTid,omitempty\" graphql:\"syobocalTid\""
+	Title             string                               "json:\"title\" graphql:\"title\""
+	ViewerStatusState *StatusState                         "json:\"viewerStatusState,omitempty\" graphql:\"viewerStatusState\""
+	WatchersCount     int64                                "json:\"watchersCount\" graphql:\"watchersCount\""
+}
+
+func (t *SearchWorks_SearchWorks_Nodes) GetAnnictID() int64 {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.AnnictID
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetEpisodesCount() int64 {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.EpisodesCount
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetID() string {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.ID
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetImage() *SearchWorks_SearchWorks_Nodes_Image {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.Image
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetMedia() *Media {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return &t.Media
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetOfficialSiteURL() *string {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.OfficialSiteURL
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetSatisfactionRate() *float64 {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.SatisfactionRate
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetSeasonName() *SeasonName {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.SeasonName
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetSeasonYear() *int64 {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.SeasonYear
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetSyobocalTid() *int64 {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.SyobocalTid
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetTitle() string {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.Title
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetViewerStatusState() *StatusState {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.ViewerStatusState
+}
+func (t *SearchWorks_SearchWorks_Nodes) GetWatchersCount() int64 {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_Nodes{}
+	}
+	return t.WatchersCount
+}
+
+type SearchWorks_SearchWorks_PageInfo struct {
+	EndCursor   *string "json:\"endCursor,omitempty\" graphql:\"endCursor\""
+	HasNextPage bool    "json:\"hasNextPage\" graphql:\"hasNextPage\""
+}
+
+func (t *SearchWorks_SearchWorks_PageInfo) GetEndCursor() *string {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_PageInfo{}
+	}
+	return t.EndCursor
+}
+func (t *SearchWorks_SearchWorks_PageInfo) GetHasNextPage() bool {
+	if t == nil {
+		t = &SearchWorks_SearchWorks_PageInfo{}
+	}
+	return t.HasNextPage
+}
+
+type SearchWorks_SearchWorks struct {
+	Nodes    []*SearchWorks_SearchWorks_Nodes "json:\"nodes,omitempty\" graphql:\"nodes\""
+	PageInfo SearchWorks_SearchWorks_PageInfo "json:\"pageInfo\" graphql:\"pageInfo\""
+}
+
+func (t *SearchWorks_SearchWorks) GetNodes() []*SearchWorks_SearchWorks_Nodes {
+	if t == nil {
+		t = &SearchWorks_SearchWorks{}
+	}
+	return t.Nodes
+}
+func (t *SearchWorks_SearchWorks) GetPageInfo() *SearchWorks_SearchWorks_PageInfo {
+	if t == nil {
+		t = &SearchWorks_SearchWorks{}
+	}
+	return &t.PageInfo
+}
+
+type UpdateStatus_UpdateStatus_Work struct {
+	ID                string       "json:\"id\" graphql:\"id\""
+	Title             string       "json:\"title\" graphql:\"title\""
+	ViewerStatusState *StatusState "json:\"viewerStatusState,omitempty\" graphql:\"viewerStatusState\""
+}
+
+func (t *UpdateStatus_UpdateStatus_Work) GetID() string {
+	if t == nil {
+		t = &UpdateStatus_UpdateStatus_Work{}
+	}
+	return t.ID
+}
+func (t *UpdateStatus_UpdateStatus_Work) GetTitle() string {
+	if t == nil {
+		t = &UpdateStatus_UpdateStatus_Work{}
+	}
+	return t.Title
+}
+func (t *UpdateStatus_UpdateStatus_Work) GetViewerStatusState() *StatusState {
+	if t == nil {
+		t = &UpdateStatus_UpdateStatus_Work{}
+	}
+	return t.ViewerStatusState
+}
+
+type UpdateStatus_UpdateStatus struct {
+	Work *UpdateStatus_UpdateStatus_Work "json:\"work,omitempty\" graphql:\"work\""
+}
+
+func (t *UpdateStatus_UpdateStatus) GetWork() *UpdateStatus_UpdateStatus_Work {
+	if t == nil {
+		t = &UpdateStatus_UpdateStatus{}
+	}
+	return t.Work
+}
+
+type CreateRecord struct {
+	CreateRecord *CreateRecord_CreateRecord "json:\"createRecord,omitempty\" graphql:\"createRecord\""
+}
+
+func (t *CreateRecord) GetCreateRecord() *CreateRecord_CreateRecord {
+	if t == nil {
+		t = &CreateRecord{}
+	}
+	return t.CreateRecord
+}
+
 type GetLibraryEntries struct {
 	Viewer *GetLibraryEntries_Viewer "json:\"viewer,omitempty\" graphql:\"viewer\""
 }
@@ -297,6 +860,17 @@ func (t *GetLibraryEntries) GetViewer() *GetLibraryEntries_Viewer {
 	return t.Viewer
 }
 
+type GetLibraryWorks struct {
+	Viewer *GetLibraryWorks_Viewer "json:\"viewer,omitempty\" graphql:\"viewer\""
+}
+
+func (t *GetLibraryWorks) GetViewer() *GetLibraryWorks_Viewer {
+	if t == nil {
+		t = &GetLibraryWorks{}
+	}
+	return t.Viewer
+}
+
 type GetPrograms struct {
 	Viewer *GetPrograms_Viewer "json:\"viewer,omitempty\" graphql:\"viewer\""
 }
@@ -308,13 +882,80 @@ func (t *GetPrograms) GetViewer() *GetPrograms_Viewer {
 	return t.Viewer
 }
 
-const GetLibraryEntriesDocument = `query GetLibraryEntries ($seasons: [String!]) {
+type GetViewer struct {
+	Viewer *GetViewer_Viewer "json:\"viewer,omitempty\" graphql:\"viewer\""
+}
+
+func (t *GetViewer) GetViewer() *GetViewer_Viewer {
+	if t == nil {
+		t = &GetViewer{}
+	}
+	return t.Viewer
+}
+
+type SearchWorks struct {
+	SearchWorks *SearchWorks_SearchWorks "json:\"searchWorks,omitempty\" graphql:\"searchWorks\""
+}
+
+func (t *SearchWorks) GetSearchWorks() *SearchWorks_SearchWorks {
+	if t == nil {
+		t = &SearchWorks{}
+	}
+	return t.SearchWorks
+}
+
+type UpdateStatus struct {
+	UpdateStatus *UpdateStatus_UpdateStatus "json:\"updateStatus,omitempty\" graphql:\"updateStatus\""
+}
+
+func (t *UpdateStatus) GetUpdateStatus() *UpdateStatus_UpdateStatus {
+	if t == nil {
+		t = &UpdateStatus{}
+	}
+	return t.UpdateStatus
+}
+
+const CreateRecordDocument = `mutation CreateRecord ($episodeId: ID!) {
+	createRecord(input: {episodeId:$episodeId}) {
+		record {
+			id
+			episode {
+				id
+				viewerDidTrack
+			}
+		}
+	}
+}
+`
+
+func (c *Client) CreateRecord(ctx context.Context, episodeID string, interceptors ...clientv2.RequestInterceptor) (*CreateRecord, error) {
+	vars := map[string]any{
+		"episodeId": episodeID,
+	}
+
+	var res CreateRecord
+	if err := c.Client.Post(ctx, "CreateRecord", CreateRecordDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const GetLibraryEntriesDocument = `query GetLibraryEntries ($states: [StatusState!], $seasons: [String!], $first: Int, $after: String) {
 	viewer {
-		libraryEntries(states: [WATCHING,WANNA_WATCH], seasons: $seasons, orderBy: {field:LAST_TRACKED_AT,direction:DESC}) {
+		libraryEntries(states: $states, seasons: $seasons, orderBy: {field:LAST_TRACKED_AT,direction:DESC}, first: $first, after: $after) {
 			nodes {
 				work {
+					id
+					annictId
+					syobocalTid
 					title
 					officialSiteUrl
+					viewerStatusState
 					image {
 						facebookOgImageUrl
 						recommendedImageUrl
@@ -322,23 +963,35 @@ const GetLibraryEntriesDocument = `query GetLibraryEntries ($seasons: [String!])
 				}
 				nextProgram {
 					channel {
+						annictId
 						name
+						channelGroup {
+							name
+						}
 					}
 					startedAt
 				}
 				nextEpisode {
+					id
 					numberText
 					title
 				}
 			}
+			pageInfo {
+				endCursor
+				hasNextPage
+			}
 		}
 	}
 }
 `
 
-func (c *Client) GetLibraryEntries(ctx context.Context, seasons []string, interceptors ...clientv2.RequestInterceptor) (*GetLibraryEntries, error) {
+func (c *Client) GetLibraryEntries(ctx context.Context, states []StatusState, seasons []string, first *int64, after *string, interceptors ...clientv2.RequestInterceptor) (*GetLibraryEntries, error) {
 	vars := map[string]any{
+		"states":  states,
 		"seasons": seasons,
+		"first":   first,
+		"after":   after,
 	}
 
 	var res GetLibraryEntries
@@ -353,11 +1006,52 @@ func (c *Client) GetLibraryEntries(ctx context.Context, seasons []string, interc
 	return &res, nil
 }
 
-const GetProgramsDocument = `query GetPrograms {
+const GetLibraryWorksDocument = `query GetLibraryWorks ($states: [StatusState!], $first: Int, $after: String) {
 	viewer {
-		programs(unwatched: true, orderBy: {field:STARTED_AT,direction:DESC}) {
+		libraryEntries(states: $states, first: $first, after: $after) {
 			nodes {
 				work {
+					id
+					title
+					viewerStatusState
+				}
+			}
+			pageInfo {
+				endCursor
+				hasNextPage
+			}
+		}
+	}
+}
+`
+
+func (c *Client) GetLibraryWorks(ctx context.Context, states []StatusState, first *int64, after *string, interceptors ...clientv2.RequestInterceptor) (*GetLibraryWorks, error) {
+	vars := map[string]any{
+		"states": states,
+		"first":  first,
+		"after":  after,
+	}
+
+	var res GetLibraryWorks
+	if err := c.Client.Post(ctx, "GetLibraryWorks", GetLibraryWorksDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const GetProgramsDocument = `query GetPrograms ($first: Int, $after: String) {
+	viewer {
+		programs(unwatched: true, orderBy: {field:STARTED_AT,direction:DESC}, first: $first, after: $after) {
+			nodes {
+				id
+				work {
+					annictId
+					syobocalTid
 					title
 					officialSiteUrl
 					image {
@@ -366,22 +1060,36 @@ const GetProgramsDocument = `query GetPrograms {
 					}
 				}
 				startedAt
+				rebroadcast
+				state
 				channel {
+					annictId
 					name
+					channelGroup {
+						name
+					}
 				}
 				episode {
+					id
 					number
 					numberText
 					title
 				}
 			}
+			pageInfo {
+				endCursor
+				hasNextPage
+			}
 		}
 	}
 }
 `
 
-func (c *Client) GetPrograms(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*GetPrograms, error) {
-	vars := map[string]any{}
+func (c *Client) GetPrograms(ctx context.Context, first *int64, after *string, interceptors ...clientv2.RequestInterceptor) (*GetPrograms, error) {
+	vars := map[string]any{
+		"first": first,
+		"after": after,
+	}
 
 	var res GetPrograms
 	if err := c.Client.Post(ctx, "GetPrograms", GetProgramsDocument, &res, vars, interceptors...); err != nil {
@@ -395,7 +1103,119 @@ func (c *Client) GetPrograms(ctx context.Context, interceptors ...clientv2.Reque
 	return &res, nil
 }
 
+const GetViewerDocument = `query GetViewer {
+	viewer {
+		username
+		name
+		watchingCount
+		wannaWatchCount
+		watchedCount
+		onHoldCount
+		stopWatchingCount
+		recordsCount
+	}
+}
+`
+
+func (c *Client) GetViewer(ctx context.Context, interceptors ...clientv2.RequestInterceptor) (*GetViewer, error) {
+	vars := map[string]any{}
+
+	var res GetViewer
+	if err := c.Client.Post(ctx, "GetViewer", GetViewerDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const SearchWorksDocument = `query SearchWorks ($titles: [String!], $seasons: [String!], $orderBy: WorkOrder, $first: Int, $after: String) {
+	searchWorks(titles: $titles, seasons: $seasons, orderBy: $orderBy, first: $first, after: $after) {
+		nodes {
+			id
+			annictId
+			syobocalTid
+			title
+			officialSiteUrl
+			seasonName
+			seasonYear
+			media
+			episodesCount
+			satisfactionRate
+			watchersCount
+			viewerStatusState
+			image {
+				facebookOgImageUrl
+				recommendedImageUrl
+			}
+		}
+		pageInfo {
+			endCursor
+			hasNextPage
+		}
+	}
+}
+`
+
+func (c *Client) SearchWorks(ctx context.Context, titles []string, seasons []string, orderBy *WorkOrder, first *int64, after *string, interceptors ...clientv2.RequestInterceptor) (*SearchWorks, error) {
+	vars := map[string]any{
+		"titles":  titles,
+		"seasons": seasons,
+		"orderBy": orderBy,
+		"first":   first,
+		"after":   after,
+	}
+
+	var res SearchWorks
+	if err := c.Client.Post(ctx, "SearchWorks", SearchWorksDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
+const UpdateStatusDocument = `mutation UpdateStatus ($workId: ID!, $state: StatusState!) {
+	updateStatus(input: {workId:$workId,state:$state}) {
+		work {
+			id
+			title
+			viewerStatusState
+		}
+	}
+}
+`
+
+func (c *Client) UpdateStatus(ctx context.Context, workID string, state StatusState, interceptors ...clientv2.RequestInterceptor) (*UpdateStatus, error) {
+	vars := map[string]any{
+		"workId": workID,
+		"state":  state,
+	}
+
+	var res UpdateStatus
+	if err := c.Client.Post(ctx, "UpdateStatus", UpdateStatusDocument, &res, vars, interceptors...); err != nil {
+		if c.Client.ParseDataWhenErrors {
+			return &res, err
+		}
+
+		return nil, err
+	}
+
+	return &res, nil
+}
+
 var DocumentOperationNames = map[string]string{
+	CreateRecordDocument:      "CreateRecord",
 	GetLibraryEntriesDocument: "GetLibraryEntries",
+	GetLibraryWorksDocument:   "GetLibraryWorks",
 	GetProgramsDocument:       "GetPrograms",
+	GetViewerDocument:         "GetViewer",
+	SearchWorksDocument:       "SearchWorks",
+	UpdateStatusDocument:      "UpdateStatus",
 }
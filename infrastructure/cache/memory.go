@@ -0,0 +1,76 @@
+package cache
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/usecase"
+)
+
+// MemoryCache is an in-process CacheRepository. Its contents are lost on restart.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]*usecase.CacheEntry
+}
+
+// NewMemoryCache creates an empty cache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]*usecase.CacheEntry),
+	}
+}
+
+// GetCache returns the cached entry for the key, including expired ones.
+func (c *MemoryCache) GetCache(ctx context.Context, key string) (*usecase.CacheEntry, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	entry, ok := c.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	copied := *entry
+	return &copied, true, nil
+}
+
+// SetCache creates or replaces the cached entry for the key.
+func (c *MemoryCache) SetCache(ctx context.Context, key string, entry *usecase.CacheEntry) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	copied := *entry
+	c.entries[key] = &copied
+	return nil
+}
+
+// DeleteCache removes the cached entry for the key.
+func (c *MemoryCache) DeleteCache(ctx context.Context, key string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, key)
+	return nil
+}
+
+// DeleteCacheByPrefix removes every cached entry whose key starts with the prefix.
+func (c *MemoryCache) DeleteCacheByPrefix(ctx context.Context, prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+	return nil
+}
+
+// PruneCache deletes entries under the key prefix that expired before the given time.
+func (c *MemoryCache) PruneCache(ctx context.Context, prefix string, before time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for key, entry := range c.entries {
+		if strings.HasPrefix(key, prefix) && entry.ExpiresAt.Before(before) {
+			delete(c.entries, key)
+		}
+	}
+	return nil
+}
@@ -18,11 +18,47 @@ type Config struct {
 	AnnictToken             string        `envconfig:"ANNICT_ACCESS_TOKEN" required:"true"`
 	AnnictEndpoint          string        `envconfig:"ANNICT_ENDPOINT" default:"https://api.annict.com/graphql"`
 	AnnictLimitNumToDisplay int           `envconfig:"ANNICT_LIMIT_NUM_TO_DISPLAY" default:"5"`
+	AnnictPageSize          int           `envconfig:"ANNICT_PAGE_SIZE" default:"50"`
+	AnnictMaxPages          int           `envconfig:"ANNICT_MAX_PAGES" default:"10"`
+	AnnictTokenStoreFile    string        `envconfig:"ANNICT_TOKEN_STORE_FILE" default:"annict_tokens.json"` // Deprecated: imported into STORAGE_DB_PATH on start
+	AnnictTokenKey          string        `envconfig:"ANNICT_TOKEN_ENCRYPTION_KEY"`
+	OAuthClientID           string        `envconfig:"ANNICT_OAUTH_CLIENT_ID"`
+	OAuthClientSecret       string        `envconfig:"ANNICT_OAUTH_CLIENT_SECRET"`
+	OAuthRedirectURL        string        `envconfig:"ANNICT_OAUTH_REDIRECT_URL"`
+	OAuthAuthorizeURL       string        `envconfig:"ANNICT_OAUTH_AUTHORIZE_URL" default:"https://annict.com/oauth/authorize"`
+	OAuthTokenURL           string        `envconfig:"ANNICT_OAUTH_TOKEN_URL" default:"https://api.annict.com/oauth/token"`
+	OAuthRevokeURL          string        `envconfig:"ANNICT_OAUTH_REVOKE_URL" default:"https://api.annict.com/oauth/revoke"`
+	OAuthServerAddr         string        `envconfig:"OAUTH_SERVER_ADDR"` // Deprecated: use HTTP_SERVER_ADDR
+	HTTPServerAddr          string        `envconfig:"HTTP_SERVER_ADDR" default:":8080"`
 	LogLevel                string        `envconfig:"LOG_LEVEL" default:"info"`
 	IsDevelopment           bool          `envconfig:"IS_DEVELOPMENT" default:"false"`
 	ImageCheckTimeout       time.Duration `envconfig:"IMAGE_CHECK_TIMEOUT" default:"5s"`
+	ImageCheckConcurrency   int           `envconfig:"IMAGE_CHECK_CONCURRENCY" default:"8"`
+	ImageCacheValidTTL      time.Duration `envconfig:"IMAGE_CACHE_VALID_TTL" default:"24h"`
+	ImageCacheInvalidTTL    time.Duration `envconfig:"IMAGE_CACHE_INVALID_TTL" default:"1h"`
+	ImageOGFallback         bool          `envconfig:"IMAGE_OG_FALLBACK" default:"true"`
+	ImageProxyBaseURL       string        `envconfig:"IMAGE_PROXY_BASE_URL"`
+	ImageProxySigningKey    string        `envconfig:"IMAGE_PROXY_SIGNING_KEY"`
+	ImageProxyMaxWidth      int           `envconfig:"IMAGE_PROXY_MAX_WIDTH" default:"320"`
+	ImageProxyCacheTTL      time.Duration `envconfig:"IMAGE_PROXY_CACHE_TTL" default:"24h"`
+	DayRolloverHour         int           `envconfig:"DAY_ROLLOVER_HOUR" default:"5"`
+	ThirtyHourNotation      bool          `envconfig:"THIRTY_HOUR_NOTATION" default:"true"`
 	ScheduleChannelID       string        `envconfig:"SCHEDULE_CHANNEL_ID"`
-	SlackHomeIotToken       string        `envconfig:"SLACK_HOMEIOT_TOKEN"`
+	ScheduleDailyDigestCron string        `envconfig:"SCHEDULE_DAILY_DIGEST_CRON" default:"0 7 * * *"`
+	SeasonPreviewCron       string        `envconfig:"SCHEDULE_SEASON_PREVIEW_CRON" default:"0 9 * * *"`
+	SeasonPreviewLeadDays   int           `envconfig:"SEASON_PREVIEW_LEAD_DAYS" default:"14"`
+	ScheduleCatchUpWindow   time.Duration `envconfig:"SCHEDULE_CATCHUP_WINDOW" default:"12h"`
+	ScheduleStateFile       string        `envconfig:"SCHEDULE_STATE_FILE" default:"scheduler_state.json"` // Deprecated: imported into STORAGE_DB_PATH on start
+	ReminderChannelID       string        `envconfig:"REMINDER_CHANNEL_ID"`
+	ReminderLeadTime        time.Duration `envconfig:"REMINDER_LEAD_TIME" default:"10m"`
+	ReminderSyncInterval    time.Duration `envconfig:"REMINDER_SYNC_INTERVAL" default:"30m"`
+	ReminderHistoryFile     string        `envconfig:"REMINDER_HISTORY_FILE" default:"reminder_history.json"` // Deprecated: imported into STORAGE_DB_PATH on start
+	StreamingMappingFile    string        `envconfig:"STREAMING_MAPPING_FILE"`
+	StorageDBPath           string        `envconfig:"STORAGE_DB_PATH" default:"annict_bot.db"`
+	CacheBackend            string        `envconfig:"CACHE_BACKEND" default:"memory"`
+	CacheProgramsTTL        time.Duration `envconfig:"CACHE_PROGRAMS_TTL" default:"5m"`
+	CacheLibraryTTL         time.Duration `envconfig:"CACHE_LIBRARY_TTL" default:"15m"`
+	CacheStaleTTL           time.Duration `envconfig:"CACHE_STALE_TTL" default:"1h"`
 }
 
 // LoadConfig loads configuration from environment variables (.env fallback).
@@ -37,5 +73,28 @@ func LoadConfig() (*Config, error) {
 		return nil, err
 	}
 	cfg.LogLevel = strings.ToLower(cfg.LogLevel)
+	cfg.CacheBackend = strings.ToLower(cfg.CacheBackend)
+	if cfg.OAuthServerAddr != "" {
+		cfg.HTTPServerAddr = cfg.OAuthServerAddr
+	}
+	if cfg.ReminderChannelID == "" {
+		cfg.ReminderChannelID = cfg.ScheduleChannelID
+	}
 	return &cfg, nil
 }
+
+// ImageProxyEnabled reports whether the built-in image proxy is configured.
+func (c *Config) ImageProxyEnabled() bool {
+	return c.ImageProxyBaseURL != ""
+}
+
+// LinkingEnabled reports whether Slack users can link their own Annict accounts.
+// Linked tokens are only stored encrypted, so linking requires the encryption key.
+func (c *Config) LinkingEnabled() bool {
+	return c.AnnictTokenKey != ""
+}
+
+// OAuthEnabled reports whether the Annict OAuth application is configured.
+func (c *Config) OAuthEnabled() bool {
+	return c.LinkingEnabled() && c.OAuthClientID != "" && c.OAuthClientSecret != "" && c.OAuthRedirectURL != ""
+}
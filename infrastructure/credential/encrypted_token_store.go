@@ -0,0 +1,103 @@
+package credential
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"strings"
+
+	"github.com/monchh/annict-slack-bot/usecase"
+)
+
+// encryptedPrefix marks values written by EncryptedTokenStore.
+// Values without it are plaintext tokens from before encryption and are re-encrypted on read.
+const encryptedPrefix = "enc:v1:"
+
+// EncryptedTokenStore encrypts tokens with AES-256-GCM before handing them to the underlying store.
+type EncryptedTokenStore struct {
+	store usecase.TokenStore
+	aead  cipher.AEAD
+}
+
+// ParseEncryptionKey decodes a base64 encoded 32 byte key (e.g. "openssl rand -base64 32").
+func ParseEncryptionKey(encoded string) ([]byte, error) {
+	key, err := base64.StdEncoding.DecodeString(strings.TrimSpace(encoded))
+	if err != nil {
+		return nil, fmt.Errorf("encryption key is not valid base64: %w", err)
+	}
+	if len(key) != 32 {
+		return nil, fmt.Errorf("encryption key must be 32 bytes, got %d", len(key))
+	}
+	return key, nil
+}
+
+// NewEncryptedTokenStore wraps the store with encryption under the 32 byte key.
+func NewEncryptedTokenStore(store usecase.TokenStore, key []byte) (*EncryptedTokenStore, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return &EncryptedTokenStore{
+		store: store,
+		aead:  aead,
+	}, nil
+}
+
+// Token returns the decrypted access token linked to the Slack user.
+func (s *EncryptedTokenStore) Token(ctx context.Context, slackUserID string) (string, bool, error) {
+	stored, ok, err := s.store.Token(ctx, slackUserID)
+	if err != nil || !ok {
+		return "", ok, err
+	}
+	if !strings.HasPrefix(stored, encryptedPrefix) {
+		// Plaintext token saved before encryption was enabled.
+		if err := s.SaveToken(ctx, slackUserID, stored); err != nil {
+			return "", false, fmt.Errorf("failed to re-encrypt token of %s: %w", slackUserID, err)
+		}
+		return stored, true, nil
+	}
+	token, err := s.decrypt(slackUserID, strings.TrimPrefix(stored, encryptedPrefix))
+	if err != nil {
+		return "", false, err
+	}
+	return token, true, nil
+}
+
+// SaveToken encrypts the access token and stores it for the Slack user.
+func (s *EncryptedTokenStore) SaveToken(ctx context.Context, slackUserID, token string) error {
+	nonce := make([]byte, s.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	// The Slack user ID is bound as additional data, so a value copied to another user does not decrypt.
+	sealed := s.aead.Seal(nonce, nonce, []byte(token), []byte(slackUserID))
+	return s.store.SaveToken(ctx, slackUserID, encryptedPrefix+base64.StdEncoding.EncodeToString(sealed))
+}
+
+// DeleteToken removes the Slack user's access token.
+func (s *EncryptedTokenStore) DeleteToken(ctx context.Context, slackUserID string) error {
+	return s.store.DeleteToken(ctx, slackUserID)
+}
+
+func (s *EncryptedTokenStore) decrypt(slackUserID, encoded string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode token of %s: %w", slackUserID, err)
+	}
+	nonceSize := s.aead.NonceSize()
+	if len(sealed) < nonceSize {
+		return "", fmt.Errorf("encrypted token of %s is too short", slackUserID)
+	}
+	plain, err := s.aead.Open(nil, sealed[:nonceSize], sealed[nonceSize:], []byte(slackUserID))
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt token of %s (wrong ANNICT_TOKEN_ENCRYPTION_KEY?): %w", slackUserID, err)
+	}
+	return string(plain), nil
+}
@@ -0,0 +1,159 @@
+package credential
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"strings"
+	"testing"
+)
+
+// memoryTokenStore is a TokenStore that keeps the stored values in a map.
+type memoryTokenStore struct {
+	tokens map[string]string
+}
+
+func (s *memoryTokenStore) Token(ctx context.Context, slackUserID string) (string, bool, error) {
+	token, ok := s.tokens[slackUserID]
+	return token, ok, nil
+}
+
+func (s *memoryTokenStore) SaveToken(ctx context.Context, slackUserID, token string) error {
+	s.tokens[slackUserID] = token
+	return nil
+}
+
+func (s *memoryTokenStore) DeleteToken(ctx context.Context, slackUserID string) error {
+	delete(s.tokens, slackUserID)
+	return nil
+}
+
+func testKey(b byte) []byte {
+	return bytes.Repeat([]byte{b}, 32)
+}
+
+func newTestEncryptedStore(t *testing.T, backing *memoryTokenStore, key []byte) *EncryptedTokenStore {
+	t.Helper()
+	store, err := NewEncryptedTokenStore(backing, key)
+	if err != nil {
+		t.Fatalf("NewEncryptedTokenStore returned error: %v", err)
+	}
+	return store
+}
+
+func TestEncryptedTokenStoreRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	backing := &memoryTokenStore{tokens: make(map[string]string)}
+	store := newTestEncryptedStore(t, backing, testKey(1))
+	if err := store.SaveToken(ctx, "U1", "secret-token"); err != nil {
+		t.Fatalf("SaveToken returned error: %v", err)
+	}
+
+	stored := backing.tokens["U1"]
+	if !strings.HasPrefix(stored, encryptedPrefix) || strings.Contains(stored, "secret-token") {
+		t.Errorf("stored value %q is not encrypted", stored)
+	}
+
+	// A fresh store with the same key reads the token back.
+	reloaded := newTestEncryptedStore(t, backing, testKey(1))
+	token, ok, err := reloaded.Token(ctx, "U1")
+	if err != nil || !ok || token != "secret-token" {
+		t.Errorf("Token = %q, %v, %v; want secret-token, true, nil", token, ok, err)
+	}
+	if _, ok, err := reloaded.Token(ctx, "U2"); ok || err != nil {
+		t.Errorf("Token of an unknown user = %v, %v; want false, nil", ok, err)
+	}
+}
+
+func TestEncryptedTokenStoreRejectsTamperedValues(t *testing.T) {
+	tests := []struct {
+		name   string
+		key    []byte
+		tamper func(backing *memoryTokenStore)
+	}{
+		{
+			name: "wrong key",
+			key:  testKey(2),
+		},
+		{
+			name: "value copied to another user",
+			key:  testKey(1),
+			tamper: func(backing *memoryTokenStore) {
+				backing.tokens["U1"] = backing.tokens["U2"]
+			},
+		},
+		{
+			name: "truncated value",
+			key:  testKey(1),
+			tamper: func(backing *memoryTokenStore) {
+				backing.tokens["U1"] = encryptedPrefix + base64.StdEncoding.EncodeToString([]byte("short"))
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			backing := &memoryTokenStore{tokens: make(map[string]string)}
+			store := newTestEncryptedStore(t, backing, testKey(1))
+			if err := store.SaveToken(ctx, "U1", "token-1"); err != nil {
+				t.Fatal(err)
+			}
+			if err := store.SaveToken(ctx, "U2", "token-2"); err != nil {
+				t.Fatal(err)
+			}
+			if tt.tamper != nil {
+				tt.tamper(backing)
+			}
+
+			reader := newTestEncryptedStore(t, backing, tt.key)
+			if token, ok, err := reader.Token(ctx, "U1"); err == nil || ok {
+				t.Errorf("Token = %q, %v, %v; want an error", token, ok, err)
+			}
+		})
+	}
+}
+
+func TestEncryptedTokenStoreMigratesPlaintext(t *testing.T) {
+	ctx := context.Background()
+	backing := &memoryTokenStore{tokens: map[string]string{"U1": "legacy-token"}}
+	store := newTestEncryptedStore(t, backing, testKey(1))
+
+	token, ok, err := store.Token(ctx, "U1")
+	if err != nil || !ok || token != "legacy-token" {
+		t.Fatalf("Token = %q, %v, %v; want legacy-token, true, nil", token, ok, err)
+	}
+	if stored := backing.tokens["U1"]; !strings.HasPrefix(stored, encryptedPrefix) {
+		t.Errorf("stored value %q was not re-encrypted", stored)
+	}
+	if token, _, err := store.Token(ctx, "U1"); err != nil || token != "legacy-token" {
+		t.Errorf("Token after migration = %q, %v; want legacy-token", token, err)
+	}
+}
+
+func TestParseEncryptionKey(t *testing.T) {
+	tests := []struct {
+		name    string
+		encoded string
+		wantErr bool
+	}{
+		{name: "32 bytes", encoded: base64.StdEncoding.EncodeToString(testKey(1))},
+		{name: "surrounding whitespace", encoded: " " + base64.StdEncoding.EncodeToString(testKey(1)) + "\n"},
+		{name: "placeholder is not base64", encoded: "BASE64_ENCODED_32_BYTE_KEY", wantErr: true},
+		{name: "too short", encoded: base64.StdEncoding.EncodeToString(make([]byte, 16)), wantErr: true},
+		{name: "empty", encoded: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			key, err := ParseEncryptionKey(tt.encoded)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("ParseEncryptionKey(%q) accepted an invalid key", tt.encoded)
+				}
+				return
+			}
+			if err != nil || !bytes.Equal(key, testKey(1)) {
+				t.Errorf("ParseEncryptionKey(%q) = %v, %v; want the decoded key", tt.encoded, key, err)
+			}
+		})
+	}
+}
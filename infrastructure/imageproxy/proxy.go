@@ -0,0 +1,266 @@
+package imageproxy
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	// Decoders for the formats served by Annict and official sites
+	_ "image/gif"
+	_ "image/png"
+
+	"golang.org/x/image/draw"
+
+	"github.com/monchh/annict-slack-bot/usecase"
+)
+
+const (
+	// ThumbnailPath serves resized copies of signed image URLs.
+	ThumbnailPath = "/images/thumb"
+	// PlaceholderPath serves generated images for works without a picture.
+	PlaceholderPath = "/images/placeholder"
+
+	sourceTimeout   = 15 * time.Second
+	sourceReadLimit = 10 << 20
+	// sourcePixelLimit rejects images that would take too much memory to decode;
+	// a small file can declare huge dimensions.
+	sourcePixelLimit = 4096 * 4096
+	jpegQuality      = 85
+	pruneInterval    = time.Hour
+	cacheKeyPrefix   = "imageproxy:"
+)
+
+// Config holds the settings of the proxy.
+type Config struct {
+	BaseURL    string        // Public URL of the bot's HTTP server, e.g. "https://bot.example.com"
+	SigningKey []byte        // Secret used to sign proxied URLs so the endpoint is not an open proxy
+	MaxWidth   int           // Thumbnails are scaled down to this width
+	CacheTTL   time.Duration // How long a thumbnail is kept
+}
+
+// Proxy implements usecase.ImageProxy and serves the URLs it hands out.
+type Proxy struct {
+	cfg        Config
+	signingKey []byte
+	httpClient *http.Client
+	cache      usecase.CacheRepository
+
+	mu        sync.Mutex
+	lastPrune time.Time
+}
+
+// New creates a proxy storing thumbnails in the cache.
+func New(cfg Config, client *http.Client, cache usecase.CacheRepository) *Proxy {
+	// Derive a dedicated key so the configured secret is never used for anything else directly.
+	mac := hmac.New(sha256.New, cfg.SigningKey)
+	mac.Write([]byte("annict-slack-bot/imageproxy"))
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &Proxy{
+		cfg:        cfg,
+		signingKey: mac.Sum(nil),
+		httpClient: client,
+		cache:      cache,
+	}
+}
+
+// ProxyURL returns the signed thumbnail URL of the image. The seed (usually the title)
+// picks the placeholder served when the image cannot be loaded, the same one PlaceholderURL gives.
+func (p *Proxy) ProxyURL(imageURL, seed string) string {
+	query := url.Values{}
+	query.Set("url", imageURL)
+	query.Set("sig", p.sign(imageURL))
+	query.Set("seed", placeholderSeed(seed))
+	return p.cfg.BaseURL + ThumbnailPath + "?" + query.Encode()
+}
+
+// PlaceholderURL returns the URL of the placeholder generated for the seed (usually the title).
+// Only a digest of the seed is put in the URL.
+func (p *Proxy) PlaceholderURL(seed string) string {
+	return fmt.Sprintf("%s%s?seed=%s", p.cfg.BaseURL, PlaceholderPath, placeholderSeed(seed))
+}
+
+// placeholderSeed returns the digest of the seed carried in URLs.
+func placeholderSeed(seed string) string {
+	sum := sha256.Sum256([]byte(seed))
+	return fmt.Sprintf("%x", sum[:6])
+}
+
+// Handler returns the HTTP handler serving both paths.
+func (p *Proxy) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(ThumbnailPath, p.handleThumbnail)
+	mux.HandleFunc(PlaceholderPath, p.handlePlaceholder)
+	return mux
+}
+
+func (p *Proxy) sign(imageURL string) string {
+	mac := hmac.New(sha256.New, p.signingKey)
+	mac.Write([]byte(imageURL))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// handleThumbnail serves the resized image. When the source cannot be loaded
+// a placeholder is served instead, so a URL given to Slack never breaks.
+func (p *Proxy) handleThumbnail(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	imageURL := r.URL.Query().Get("url")
+	if !hmac.Equal([]byte(r.URL.Query().Get("sig")), []byte(p.sign(imageURL))) {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	thumbnail, err := p.thumbnail(r.Context(), imageURL)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("Serving placeholder for image %s: %v", imageURL, err))
+		writeImage(w, placeholder(r.URL.Query().Get("seed"), p.cfg.MaxWidth), time.Hour)
+		return
+	}
+	writeImage(w, thumbnail, p.cfg.CacheTTL)
+}
+
+func (p *Proxy) handlePlaceholder(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		http.Error(w, "Method Not Allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	// The image depends only on the seed, so it can be cached for good.
+	writeImage(w, placeholder(r.URL.Query().Get("seed"), p.cfg.MaxWidth), 30*24*time.Hour)
+}
+
+// thumbnail returns the cached thumbnail of the image, creating it when missing.
+func (p *Proxy) thumbnail(ctx context.Context, imageURL string) ([]byte, error) {
+	key := cacheKeyPrefix + p.sign(imageURL)
+	if entry, ok, err := p.cache.GetCache(ctx, key); err != nil {
+		slog.Warn(fmt.Sprintf("Failed to read thumbnail cache: %v", err))
+	} else if ok && time.Now().Before(entry.ExpiresAt) {
+		return entry.Value, nil
+	}
+
+	thumbnail, err := p.render(ctx, imageURL)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	if err := p.cache.SetCache(ctx, key, &usecase.CacheEntry{Value: thumbnail, StoredAt: now, ExpiresAt: now.Add(p.cfg.CacheTTL)}); err != nil {
+		slog.Warn(fmt.Sprintf("Failed to write thumbnail cache: %v", err))
+	}
+	p.prune(ctx, now)
+	return thumbnail, nil
+}
+
+// render downloads the image and scales it down to the configured width.
+func (p *Proxy) render(ctx context.Context, imageURL string) ([]byte, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, sourceTimeout)
+	defer cancel()
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, imageURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, sourceReadLimit))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read image: %w", err)
+	}
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if config.Width <= 0 || config.Height <= 0 || config.Width*config.Height > sourcePixelLimit {
+		return nil, fmt.Errorf("image of %dx%d pixels is out of bounds", config.Width, config.Height)
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+	bounds := src.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	if width > p.cfg.MaxWidth {
+		height = height * p.cfg.MaxWidth / width
+		width = p.cfg.MaxWidth
+	}
+	dst := image.NewRGBA(image.Rect(0, 0, width, max(height, 1)))
+	// JPEG has no alpha channel, so transparent images are flattened onto white.
+	draw.Draw(dst, dst.Bounds(), image.White, image.Point{}, draw.Src)
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, bounds, draw.Over, nil)
+
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, dst, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, fmt.Errorf("failed to encode thumbnail: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// prune drops expired thumbnails at most once per interval.
+func (p *Proxy) prune(ctx context.Context, now time.Time) {
+	p.mu.Lock()
+	if now.Sub(p.lastPrune) < pruneInterval {
+		p.mu.Unlock()
+		return
+	}
+	p.lastPrune = now
+	p.mu.Unlock()
+	if err := p.cache.PruneCache(ctx, cacheKeyPrefix, now); err != nil {
+		slog.Warn(fmt.Sprintf("Failed to prune thumbnail cache: %v", err))
+	}
+}
+
+// placeholder draws a square with a diagonal gradient whose colors derive from the seed,
+// so every work keeps the same, distinguishable placeholder.
+func placeholder(seed string, size int) []byte {
+	sum := sha256.Sum256([]byte(seed))
+	from := color.RGBA{R: 64 + sum[0]/2, G: 64 + sum[1]/2, B: 64 + sum[2]/2, A: 255}
+	to := color.RGBA{R: 64 + sum[3]/2, G: 64 + sum[4]/2, B: 64 + sum[5]/2, A: 255}
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			t := float64(x+y) / float64(2*size)
+			img.SetRGBA(x, y, color.RGBA{
+				R: lerp(from.R, to.R, t),
+				G: lerp(from.G, to.G, t),
+				B: lerp(from.B, to.B, t),
+				A: 255,
+			})
+		}
+	}
+	var buf bytes.Buffer
+	_ = jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality})
+	return buf.Bytes()
+}
+
+func lerp(a, b uint8, t float64) uint8 {
+	return uint8(float64(a) + (float64(b)-float64(a))*t)
+}
+
+func writeImage(w http.ResponseWriter, body []byte, maxAge time.Duration) {
+	w.Header().Set("Content-Type", "image/jpeg")
+	w.Header().Set("Content-Length", fmt.Sprint(len(body)))
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d", int(maxAge.Seconds())))
+	w.Write(body)
+}
@@ -0,0 +1,134 @@
+package imageproxy
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/infrastructure/cache"
+)
+
+func encodePNG(t *testing.T, width, height int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, width, height))); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+// gifHeader returns a GIF that only declares its dimensions, like a decompression bomb.
+func gifHeader(width, height uint16) []byte {
+	header := []byte("GIF89a")
+	header = binary.LittleEndian.AppendUint16(header, width)
+	header = binary.LittleEndian.AppendUint16(header, height)
+	return append(header, 0, 0, 0)
+}
+
+func newTestProxy(t *testing.T, images map[string][]byte) (*Proxy, *httptest.Server) {
+	t.Helper()
+	source := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := images[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write(body)
+	}))
+	t.Cleanup(source.Close)
+	p := New(Config{
+		BaseURL:    "https://bot.example.com/",
+		SigningKey: []byte("key"),
+		MaxWidth:   100,
+		CacheTTL:   time.Hour,
+	}, source.Client(), cache.NewMemoryCache())
+	return p, source
+}
+
+func TestRender(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       []byte
+		wantWidth  int
+		wantHeight int
+		wantErr    bool
+	}{
+		{name: "wide image is scaled down", body: encodePNG(t, 400, 200), wantWidth: 100, wantHeight: 50},
+		{name: "small image keeps its size", body: encodePNG(t, 60, 30), wantWidth: 60, wantHeight: 30},
+		{name: "oversized dimensions are rejected before decoding", body: gifHeader(65535, 65535), wantErr: true},
+		{name: "not an image", body: []byte("<html></html>"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, source := newTestProxy(t, map[string][]byte{"/image": tt.body})
+			thumbnail, err := p.render(context.Background(), source.URL+"/image")
+			if tt.wantErr {
+				if err == nil {
+					t.Error("render returned no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("render returned error: %v", err)
+			}
+			config, format, err := image.DecodeConfig(bytes.NewReader(thumbnail))
+			if err != nil || format != "jpeg" {
+				t.Fatalf("thumbnail is not a JPEG: %s, %v", format, err)
+			}
+			if config.Width != tt.wantWidth || config.Height != tt.wantHeight {
+				t.Errorf("thumbnail is %dx%d, want %dx%d", config.Width, config.Height, tt.wantWidth, tt.wantHeight)
+			}
+		})
+	}
+}
+
+func serve(t *testing.T, p *Proxy, rawURL string) (int, []byte) {
+	t.Helper()
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	p.Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, parsed.RequestURI(), nil))
+	body, _ := io.ReadAll(rec.Body)
+	return rec.Code, body
+}
+
+func TestThumbnailFallsBackToTitlePlaceholder(t *testing.T) {
+	p, source := newTestProxy(t, map[string][]byte{"/bomb": gifHeader(65535, 65535)})
+
+	thumbnailURL := p.ProxyURL(source.URL+"/bomb", "葬送のフリーレン")
+	if !strings.HasPrefix(thumbnailURL, "https://bot.example.com"+ThumbnailPath+"?") {
+		t.Fatalf("ProxyURL = %q, want a URL under the base URL", thumbnailURL)
+	}
+	status, thumbnail := serve(t, p, thumbnailURL)
+	if status != http.StatusOK {
+		t.Fatalf("thumbnail status = %d, want %d", status, http.StatusOK)
+	}
+	_, placeholder := serve(t, p, p.PlaceholderURL("葬送のフリーレン"))
+	if !bytes.Equal(thumbnail, placeholder) {
+		t.Error("failed thumbnail does not serve the placeholder of the title")
+	}
+	_, other := serve(t, p, p.PlaceholderURL("ぼっち・ざ・ろっく！"))
+	if bytes.Equal(placeholder, other) {
+		t.Error("different titles share a placeholder")
+	}
+}
+
+func TestThumbnailRejectsBadSignature(t *testing.T) {
+	p, source := newTestProxy(t, map[string][]byte{"/image": encodePNG(t, 10, 10)})
+	thumbnailURL := p.ProxyURL(source.URL+"/image", "title")
+	tampered := strings.Replace(thumbnailURL, url.QueryEscape(source.URL+"/image"), url.QueryEscape(source.URL+"/other"), 1)
+	if status, _ := serve(t, p, tampered); status != http.StatusForbidden {
+		t.Errorf("status = %d for a URL that was not signed, want %d", status, http.StatusForbidden)
+	}
+}
@@ -0,0 +1,138 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Endpoints of Annict's OAuth2 provider. They are configurable so the flow
+// can be exercised against a local fake server.
+const (
+	DefaultAuthorizeURL = "https://annict.com/oauth/authorize"
+	DefaultTokenURL     = "https://api.annict.com/oauth/token"
+	DefaultRevokeURL    = "https://api.annict.com/oauth/revoke"
+)
+
+// Config holds the OAuth application settings registered on Annict.
+type Config struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	Scope        string
+	AuthorizeURL string
+	TokenURL     string
+	RevokeURL    string
+}
+
+// Client implements Annict's OAuth2 authorization-code flow.
+type Client struct {
+	config     Config
+	httpClient *http.Client
+}
+
+// NewClient creates a new OAuth client. Empty endpoints fall back to Annict's.
+func NewClient(config Config, httpClient *http.Client) *Client {
+	if config.AuthorizeURL == "" {
+		config.AuthorizeURL = DefaultAuthorizeURL
+	}
+	if config.TokenURL == "" {
+		config.TokenURL = DefaultTokenURL
+	}
+	if config.RevokeURL == "" {
+		config.RevokeURL = DefaultRevokeURL
+	}
+	if config.Scope == "" {
+		config.Scope = "read write"
+	}
+	return &Client{
+		config:     config,
+		httpClient: httpClient,
+	}
+}
+
+// AuthorizeURL returns the URL the user opens to grant the bot access.
+func (c *Client) AuthorizeURL(state string) string {
+	params := url.Values{
+		"client_id":     {c.config.ClientID},
+		"response_type": {"code"},
+		"redirect_uri":  {c.config.RedirectURL},
+		"scope":         {c.config.Scope},
+		"state":         {state},
+	}
+	separator := "?"
+	if strings.Contains(c.config.AuthorizeURL, "?") {
+		separator = "&"
+	}
+	return c.config.AuthorizeURL + separator + params.Encode()
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
+}
+
+// Exchange trades an authorization code for an access token.
+func (c *Client) Exchange(ctx context.Context, code string) (string, error) {
+	if code == "" {
+		return "", fmt.Errorf("authorization code is empty")
+	}
+	body, err := c.postForm(ctx, c.config.TokenURL, url.Values{
+		"client_id":     {c.config.ClientID},
+		"client_secret": {c.config.ClientSecret},
+		"grant_type":    {"authorization_code"},
+		"redirect_uri":  {c.config.RedirectURL},
+		"code":          {code},
+	})
+	if err != nil {
+		return "", err
+	}
+	var token tokenResponse
+	if err := json.Unmarshal(body, &token); err != nil {
+		return "", fmt.Errorf("failed to parse token response: %w", err)
+	}
+	if token.AccessToken == "" {
+		return "", fmt.Errorf("token response has no access_token")
+	}
+	return token.AccessToken, nil
+}
+
+// Revoke invalidates an access token on Annict.
+func (c *Client) Revoke(ctx context.Context, token string) error {
+	_, err := c.postForm(ctx, c.config.RevokeURL, url.Values{
+		"client_id":     {c.config.ClientID},
+		"client_secret": {c.config.ClientSecret},
+		"token":         {token},
+	})
+	return err
+}
+
+// postForm sends a form-encoded POST and returns the body of a 2xx response.
+func (c *Client) postForm(ctx context.Context, endpoint string, form url.Values) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request for %s: %w", endpoint, err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request to %s failed: %w", endpoint, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response from %s: %w", endpoint, err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("%s returned status %d: %s", endpoint, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+	return body, nil
+}
@@ -0,0 +1,272 @@
+package oauth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/usecase"
+)
+
+// fakeAnnict is a local stand-in for Annict's token and revoke endpoints.
+type fakeAnnict struct {
+	mu      sync.Mutex
+	revoked []string
+}
+
+func (f *fakeAnnict) start(t *testing.T) *httptest.Server {
+	t.Helper()
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if r.PostForm.Get("grant_type") != "authorization_code" || r.PostForm.Get("client_secret") != "secret" ||
+			r.PostForm.Get("code") != "valid-code" {
+			http.Error(w, `{"error":"invalid_grant"}`, http.StatusUnauthorized)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"access_token": "access-token", "token_type": "Bearer"})
+	})
+	mux.HandleFunc("/oauth/revoke", func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		f.mu.Lock()
+		f.revoked = append(f.revoked, r.PostForm.Get("token"))
+		f.mu.Unlock()
+		w.Write([]byte("{}"))
+	})
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return server
+}
+
+// memoryTokenStore is an in-memory usecase.TokenStore.
+type memoryTokenStore struct {
+	tokens map[string]string
+}
+
+func (s *memoryTokenStore) Token(ctx context.Context, slackUserID string) (string, bool, error) {
+	token, ok := s.tokens[slackUserID]
+	return token, ok, nil
+}
+
+func (s *memoryTokenStore) SaveToken(ctx context.Context, slackUserID, token string) error {
+	s.tokens[slackUserID] = token
+	return nil
+}
+
+func (s *memoryTokenStore) DeleteToken(ctx context.Context, slackUserID string) error {
+	delete(s.tokens, slackUserID)
+	return nil
+}
+
+type fakeVerifier struct{}
+
+func (fakeVerifier) FetchViewer(ctx context.Context, token string) (*entity.Viewer, error) {
+	return &entity.Viewer{Username: "user-of-" + token}, nil
+}
+
+type recordingNotifier struct {
+	linked []string
+}
+
+func (n *recordingNotifier) NotifyAccountLinked(ctx context.Context, slackUserID string, viewer *entity.Viewer) {
+	n.linked = append(n.linked, slackUserID)
+}
+
+type linkFixture struct {
+	annict   *fakeAnnict
+	tokens   *memoryTokenStore
+	notifier *recordingNotifier
+	linker   *usecase.AccountLinker
+	handler  *CallbackHandler
+}
+
+func newLinkFixture(t *testing.T) *linkFixture {
+	t.Helper()
+	annict := &fakeAnnict{}
+	server := annict.start(t)
+	client := NewClient(Config{
+		ClientID:     "client",
+		ClientSecret: "secret",
+		RedirectURL:  "https://bot.example.com" + CallbackPath,
+		AuthorizeURL: server.URL + "/oauth/authorize",
+		TokenURL:     server.URL + "/oauth/token",
+		RevokeURL:    server.URL + "/oauth/revoke",
+	}, server.Client())
+	tokens := &memoryTokenStore{tokens: make(map[string]string)}
+	linker := usecase.NewAccountLinker(tokens, fakeVerifier{}, client, NewMemoryStateStore())
+	notifier := &recordingNotifier{}
+	return &linkFixture{
+		annict:   annict,
+		tokens:   tokens,
+		notifier: notifier,
+		linker:   linker,
+		handler:  NewCallbackHandler(linker, notifier),
+	}
+}
+
+// startLink runs "annict link" for the user and returns the state in the authorize URL.
+func (f *linkFixture) startLink(t *testing.T, slackUserID string) string {
+	t.Helper()
+	authorizeURL, err := f.linker.StartLink(context.Background(), slackUserID)
+	if err != nil {
+		t.Fatalf("StartLink returned error: %v", err)
+	}
+	parsed, err := url.Parse(authorizeURL)
+	if err != nil {
+		t.Fatalf("authorize URL %q is invalid: %v", authorizeURL, err)
+	}
+	state := parsed.Query().Get("state")
+	if state == "" {
+		t.Fatalf("authorize URL %q has no state", authorizeURL)
+	}
+	return state
+}
+
+// callback simulates Annict redirecting the browser back to the bot.
+func (f *linkFixture) callback(state, code string) int {
+	query := url.Values{"state": {state}, "code": {code}}
+	rec := httptest.NewRecorder()
+	f.handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, CallbackPath+"?"+query.Encode(), nil))
+	return rec.Code
+}
+
+func TestCallbackLinksAccount(t *testing.T) {
+	tests := []struct {
+		name       string
+		state      func(f *linkFixture) string
+		code       string
+		wantStatus int
+		wantLinked bool
+	}{
+		{
+			name:       "valid code exchange",
+			state:      func(f *linkFixture) string { return f.startLink(t, "U1") },
+			code:       "valid-code",
+			wantStatus: http.StatusOK,
+			wantLinked: true,
+		},
+		{
+			name:       "state that was never issued",
+			state:      func(f *linkFixture) string { f.startLink(t, "U1"); return "forged-state" },
+			code:       "valid-code",
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "code rejected by Annict",
+			state:      func(f *linkFixture) string { return f.startLink(t, "U1") },
+			code:       "wrong-code",
+			wantStatus: http.StatusBadGateway,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newLinkFixture(t)
+			if got := f.callback(tt.state(f), tt.code); got != tt.wantStatus {
+				t.Errorf("status = %d, want %d", got, tt.wantStatus)
+			}
+			token, ok := f.tokens.tokens["U1"]
+			if ok != tt.wantLinked {
+				t.Fatalf("linked = %v, want %v", ok, tt.wantLinked)
+			}
+			if tt.wantLinked {
+				if token != "access-token" {
+					t.Errorf("token = %q, want access-token", token)
+				}
+				if len(f.notifier.linked) != 1 || f.notifier.linked[0] != "U1" {
+					t.Errorf("notified %v, want [U1]", f.notifier.linked)
+				}
+			} else if len(f.notifier.linked) != 0 {
+				t.Errorf("notified %v, want nobody", f.notifier.linked)
+			}
+		})
+	}
+}
+
+func TestCallbackRejectsReplayedState(t *testing.T) {
+	f := newLinkFixture(t)
+	state := f.startLink(t, "U1")
+	if got := f.callback(state, "valid-code"); got != http.StatusOK {
+		t.Fatalf("first callback status = %d, want %d", got, http.StatusOK)
+	}
+	delete(f.tokens.tokens, "U1")
+
+	if got := f.callback(state, "valid-code"); got != http.StatusBadRequest {
+		t.Errorf("replayed callback status = %d, want %d", got, http.StatusBadRequest)
+	}
+	if _, ok := f.tokens.tokens["U1"]; ok {
+		t.Error("replayed callback linked the account again")
+	}
+}
+
+func TestMemoryStateStoreExpiry(t *testing.T) {
+	store := NewMemoryStateStore()
+	now := time.Now()
+	if err := store.Save("state", "U1", now.Add(time.Minute)); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := store.Consume("state", now.Add(2*time.Minute)); ok {
+		t.Error("expired state was accepted")
+	}
+	if _, ok, _ := store.Consume("state", now); ok {
+		t.Error("state was accepted after it had been consumed")
+	}
+}
+
+func TestUnlinkRevokesToken(t *testing.T) {
+	f := newLinkFixture(t)
+	f.tokens.tokens["U1"] = "access-token"
+
+	if err := f.linker.Unlink(context.Background(), "U1"); err != nil {
+		t.Fatalf("Unlink returned error: %v", err)
+	}
+	if _, ok := f.tokens.tokens["U1"]; ok {
+		t.Error("token was not deleted")
+	}
+	f.annict.mu.Lock()
+	defer f.annict.mu.Unlock()
+	if len(f.annict.revoked) != 1 || f.annict.revoked[0] != "access-token" {
+		t.Errorf("revoked %v, want [access-token]", f.annict.revoked)
+	}
+}
+
+func TestClientExchange(t *testing.T) {
+	annict := &fakeAnnict{}
+	server := annict.start(t)
+	client := NewClient(Config{ClientID: "client", ClientSecret: "secret", TokenURL: server.URL + "/oauth/token"}, server.Client())
+	tests := []struct {
+		name      string
+		code      string
+		wantToken string
+		wantErr   bool
+	}{
+		{name: "valid code", code: "valid-code", wantToken: "access-token"},
+		{name: "rejected code", code: "wrong-code", wantErr: true},
+		{name: "empty code", code: "", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := client.Exchange(context.Background(), tt.code)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Exchange(%q) returned no error", tt.code)
+				}
+				return
+			}
+			if err != nil || token != tt.wantToken {
+				t.Errorf("Exchange(%q) = %q, %v; want %q", tt.code, token, err, tt.wantToken)
+			}
+		})
+	}
+}
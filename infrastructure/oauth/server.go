@@ -0,0 +1,81 @@
+package oauth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"log/slog"
+	"net/http"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/usecase"
+)
+
+// CallbackPath is the path of the redirect URI registered on Annict.
+const CallbackPath = "/oauth/callback"
+
+// LinkCompleter defines the method needed from the account link use case.
+type LinkCompleter interface {
+	CompleteLink(ctx context.Context, state, code string) (string, *entity.Viewer, error)
+}
+
+// LinkNotifier is told when a Slack user has linked an account through the browser.
+type LinkNotifier interface {
+	NotifyAccountLinked(ctx context.Context, slackUserID string, viewer *entity.Viewer)
+}
+
+// CallbackHandler receives the redirect after a user authorizes the bot on Annict.
+type CallbackHandler struct {
+	linker   LinkCompleter
+	notifier LinkNotifier
+}
+
+// NewCallbackHandler creates the handler to be mounted at CallbackPath.
+func NewCallbackHandler(linker LinkCompleter, notifier LinkNotifier) *CallbackHandler {
+	return &CallbackHandler{
+		linker:   linker,
+		notifier: notifier,
+	}
+}
+
+// ServeHTTP completes the authorization-code flow started by "annict link".
+func (s *CallbackHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		w.Header().Set("Allow", http.MethodGet)
+		writeResult(w, http.StatusMethodNotAllowed, "Method Not Allowed")
+		return
+	}
+	query := r.URL.Query()
+	if oauthErr := query.Get("error"); oauthErr != "" {
+		slog.Info(fmt.Sprintf("OAuth authorization was not granted: %s", oauthErr))
+		writeResult(w, http.StatusBadRequest, "Annict との連携がキャンセルされました。")
+		return
+	}
+
+	slackUserID, viewer, err := s.linker.CompleteLink(r.Context(), query.Get("state"), query.Get("code"))
+	switch {
+	case errors.Is(err, usecase.ErrInvalidLinkState):
+		slog.Info("Rejected OAuth callback with an invalid state")
+		writeResult(w, http.StatusBadRequest, "このリンクは期限切れか、すでに使用されています。Slack で `annict link` をやり直してください。")
+		return
+	case err != nil:
+		slog.Error(fmt.Sprintf("Failed to complete OAuth link for user %s: %v", slackUserID, err))
+		writeResult(w, http.StatusBadGateway, "Annict との連携に失敗しました。時間をおいて再度お試しください。")
+		return
+	}
+
+	slog.Info(fmt.Sprintf("Linked Slack user %s to Annict user %s via OAuth", slackUserID, viewer.Username))
+	s.notifier.NotifyAccountLinked(r.Context(), slackUserID, viewer)
+	writeResult(w, http.StatusOK, fmt.Sprintf("Annict アカウント @%s と連携しました。Slack に戻ってください。", viewer.Username))
+}
+
+// writeResult renders a minimal HTML page. The callback URL carries a one-time code,
+// so the page is never cached and leaks no referrer.
+func writeResult(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.WriteHeader(status)
+	fmt.Fprintf(w, "<!DOCTYPE html>\n<html lang=\"ja\"><head><meta charset=\"utf-8\"><title>Annict Slack Bot</title></head><body><p>%s</p></body></html>\n", html.EscapeString(message))
+}
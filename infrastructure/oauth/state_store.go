@@ -0,0 +1,55 @@
+package oauth
+
+import (
+	"sync"
+	"time"
+)
+
+type pendingState struct {
+	slackUserID string
+	expiresAt   time.Time
+}
+
+// MemoryStateStore keeps pending OAuth states in memory.
+// States are short-lived, so losing them on restart only means the user runs "annict link" again.
+type MemoryStateStore struct {
+	mu     sync.Mutex
+	states map[string]pendingState
+}
+
+// NewMemoryStateStore creates an empty state store.
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{
+		states: make(map[string]pendingState),
+	}
+}
+
+// Save records a state issued to the Slack user.
+func (s *MemoryStateStore) Save(state, slackUserID string, expiresAt time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	now := time.Now()
+	for key, pending := range s.states {
+		if !now.Before(pending.expiresAt) {
+			delete(s.states, key)
+		}
+	}
+	s.states[state] = pendingState{slackUserID: slackUserID, expiresAt: expiresAt}
+	return nil
+}
+
+// Consume removes the state and returns the Slack user it was issued to.
+// Unknown and expired states are reported as not found.
+func (s *MemoryStateStore) Consume(state string, now time.Time) (string, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	pending, ok := s.states[state]
+	if !ok {
+		return "", false, nil
+	}
+	delete(s.states, state)
+	if !now.Before(pending.expiresAt) {
+		return "", false, nil
+	}
+	return pending.slackUserID, true, nil
+}
@@ -0,0 +1,160 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+	"github.com/robfig/cron/v3"
+)
+
+// Job is a task run on a cron schedule.
+type Job struct {
+	Name string
+	Spec string // Standard 5-field cron expression, evaluated in JST
+	Run  func(ctx context.Context) error
+}
+
+// StateStore persists the last scheduled run time of each job.
+type StateStore interface {
+	LastRun(ctx context.Context, name string) (t time.Time, ok bool, err error)
+	SetLastRun(ctx context.Context, name string, t time.Time) error
+}
+
+type scheduledJob struct {
+	Job
+	schedule cron.Schedule
+}
+
+// maxRetries is how many times a failed run is retried before waiting for the next run.
+const maxRetries = 3
+
+// Scheduler runs jobs on cron schedules inside the bot process.
+// Runs missed while the process was down are caught up once on start (if they are
+// within the catch-up window), and the scheduled time is persisted before each run
+// so a restart never repeats a run. A run that returns an error is retried a few times.
+type Scheduler struct {
+	store         StateStore
+	catchUpWindow time.Duration
+	retryDelay    time.Duration
+	jobs          []scheduledJob
+}
+
+// New creates a new Scheduler.
+func New(store StateStore, catchUpWindow time.Duration) *Scheduler {
+	return &Scheduler{
+		store:         store,
+		catchUpWindow: catchUpWindow,
+		retryDelay:    time.Minute,
+	}
+}
+
+// Add registers a job. It returns an error if the cron expression is invalid.
+func (s *Scheduler) Add(job Job) error {
+	schedule, err := cron.ParseStandard(job.Spec)
+	if err != nil {
+		return fmt.Errorf("invalid cron expression %q for job %s: %w", job.Spec, job.Name, err)
+	}
+	s.jobs = append(s.jobs, scheduledJob{Job: job, schedule: schedule})
+	return nil
+}
+
+// Run starts all jobs and blocks until the context is cancelled.
+func (s *Scheduler) Run(ctx context.Context) {
+	slog.Info(fmt.Sprintf("Starting scheduler with %d job(s)...", len(s.jobs)))
+	var wg sync.WaitGroup
+	for _, job := range s.jobs {
+		wg.Add(1)
+		go func(job scheduledJob) {
+			defer wg.Done()
+			s.runJob(ctx, job)
+		}(job)
+	}
+	wg.Wait()
+	slog.Info("Scheduler shut down.")
+}
+
+// runJob catches up on a missed run, then waits for and executes each scheduled run.
+func (s *Scheduler) runJob(ctx context.Context, job scheduledJob) {
+	last := s.catchUp(ctx, job, jst.Now())
+	for {
+		next := job.schedule.Next(last.In(jst.Location()))
+		slog.Info(fmt.Sprintf("Job %s: next run at %s", job.Name, next.Format(time.RFC3339)))
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		s.fire(ctx, job, next)
+		last = next
+	}
+}
+
+// catchUp runs the most recent missed run (if any) and returns the time to schedule from.
+func (s *Scheduler) catchUp(ctx context.Context, job scheduledJob, now time.Time) time.Time {
+	last, ok, err := s.store.LastRun(ctx, job.Name)
+	if err != nil {
+		slog.Error(fmt.Sprintf("Job %s: failed to load last run: %v", job.Name, err))
+	}
+	if err != nil || !ok {
+		// First start: schedule from now instead of replaying history.
+		if err := s.store.SetLastRun(ctx, job.Name, now); err != nil {
+			slog.Error(fmt.Sprintf("Job %s: failed to persist last run: %v", job.Name, err))
+		}
+		return now
+	}
+
+	var missed time.Time
+	for t := job.schedule.Next(last.In(jst.Location())); !t.After(now); t = job.schedule.Next(t) {
+		missed = t
+	}
+	if missed.IsZero() {
+		return last
+	}
+	if now.Sub(missed) > s.catchUpWindow {
+		slog.Info(fmt.Sprintf("Job %s: skipped missed run at %s (older than %s)", job.Name, missed.Format(time.RFC3339), s.catchUpWindow))
+		if err := s.store.SetLastRun(ctx, job.Name, missed); err != nil {
+			slog.Error(fmt.Sprintf("Job %s: failed to persist last run: %v", job.Name, err))
+		}
+		return missed
+	}
+	slog.Info(fmt.Sprintf("Job %s: catching up missed run at %s", job.Name, missed.Format(time.RFC3339)))
+	s.fire(ctx, job, missed)
+	return missed
+}
+
+// fire persists the scheduled time and then runs the job, retrying it after retryDelay
+// while it fails. The job is skipped if the time cannot be persisted, since it could
+// otherwise run twice.
+func (s *Scheduler) fire(ctx context.Context, job scheduledJob, scheduled time.Time) {
+	if err := s.store.SetLastRun(ctx, job.Name, scheduled); err != nil {
+		slog.Error(fmt.Sprintf("Job %s: failed to persist last run, skipping run at %s: %v", job.Name, scheduled.Format(time.RFC3339), err))
+		return
+	}
+	slog.Info(fmt.Sprintf("Job %s: running (scheduled at %s)", job.Name, scheduled.Format(time.RFC3339)))
+	for attempt := 0; ; attempt++ {
+		err := job.Run(ctx)
+		if err == nil {
+			return
+		}
+		if attempt == maxRetries {
+			slog.Error(fmt.Sprintf("Job %s: run failed, giving up after %d retries: %v", job.Name, maxRetries, err))
+			return
+		}
+		slog.Error(fmt.Sprintf("Job %s: run failed, retrying in %s: %v", job.Name, s.retryDelay, err))
+
+		timer := time.NewTimer(s.retryDelay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+)
+
+// memoryStateStore is an in-memory StateStore.
+type memoryStateStore struct {
+	state  map[string]time.Time
+	setErr error
+}
+
+func (s *memoryStateStore) LastRun(ctx context.Context, name string) (time.Time, bool, error) {
+	t, ok := s.state[name]
+	return t, ok, nil
+}
+
+func (s *memoryStateStore) SetLastRun(ctx context.Context, name string, t time.Time) error {
+	if s.setErr != nil {
+		return s.setErr
+	}
+	s.state[name] = t
+	return nil
+}
+
+func jstTime(day, hour, minute int) time.Time {
+	return time.Date(2026, time.October, day, hour, minute, 0, 0, jst.Location())
+}
+
+func TestCatchUp(t *testing.T) {
+	const window = 12 * time.Hour
+	tests := []struct {
+		name     string
+		lastRun  *time.Time // nil when the job never ran
+		now      time.Time
+		setErr   error
+		wantRuns int
+		wantFrom time.Time
+		wantLast time.Time // Persisted last run, zero when nothing may be persisted
+	}{
+		{
+			name:     "first start schedules from now",
+			now:      jstTime(16, 10, 0),
+			wantFrom: jstTime(16, 10, 0),
+			wantLast: jstTime(16, 10, 0),
+		},
+		{
+			name:     "nothing missed",
+			lastRun:  ptr(jstTime(16, 7, 0)),
+			now:      jstTime(16, 10, 0),
+			wantFrom: jstTime(16, 7, 0),
+			wantLast: jstTime(16, 7, 0),
+		},
+		{
+			name:     "missed run within the window is run once",
+			lastRun:  ptr(jstTime(15, 7, 0)),
+			now:      jstTime(16, 10, 0),
+			wantRuns: 1,
+			wantFrom: jstTime(16, 7, 0),
+			wantLast: jstTime(16, 7, 0),
+		},
+		{
+			name:     "only the latest of several missed runs is run",
+			lastRun:  ptr(jstTime(12, 7, 0)),
+			now:      jstTime(16, 8, 0),
+			wantRuns: 1,
+			wantFrom: jstTime(16, 7, 0),
+			wantLast: jstTime(16, 7, 0),
+		},
+		{
+			name:     "missed run older than the window is skipped",
+			lastRun:  ptr(jstTime(15, 7, 0)),
+			now:      jstTime(16, 20, 0),
+			wantFrom: jstTime(16, 7, 0),
+			wantLast: jstTime(16, 7, 0),
+		},
+		{
+			name:     "run is skipped when its time cannot be persisted",
+			lastRun:  ptr(jstTime(15, 7, 0)),
+			now:      jstTime(16, 10, 0),
+			setErr:   errors.New("disk full"),
+			wantFrom: jstTime(16, 7, 0),
+			wantLast: jstTime(15, 7, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := &memoryStateStore{state: map[string]time.Time{}, setErr: tt.setErr}
+			if tt.lastRun != nil {
+				store.state["digest"] = *tt.lastRun
+			}
+			s := New(store, window)
+			runs := 0
+			err := s.Add(Job{Name: "digest", Spec: "0 7 * * *", Run: func(ctx context.Context) error {
+				runs++
+				return nil
+			}})
+			if err != nil {
+				t.Fatalf("Add returned error: %v", err)
+			}
+
+			from := s.catchUp(context.Background(), s.jobs[0], tt.now)
+			if runs != tt.wantRuns {
+				t.Errorf("runs = %d, want %d", runs, tt.wantRuns)
+			}
+			if !from.Equal(tt.wantFrom) {
+				t.Errorf("scheduled from %s, want %s", from, tt.wantFrom)
+			}
+			if last := store.state["digest"]; !last.Equal(tt.wantLast) {
+				t.Errorf("persisted last run %s, want %s", last, tt.wantLast)
+			}
+		})
+	}
+}
+
+func TestFireRetriesFailedRuns(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int // Runs that fail before the job succeeds
+		wantRuns int
+	}{
+		{name: "successful run is not repeated", failures: 0, wantRuns: 1},
+		{name: "failed run is retried until it succeeds", failures: 2, wantRuns: 3},
+		{name: "retries are bounded", failures: 10, wantRuns: maxRetries + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := New(&memoryStateStore{state: map[string]time.Time{}}, time.Hour)
+			s.retryDelay = time.Millisecond
+			runs := 0
+			err := s.Add(Job{Name: "digest", Spec: "0 7 * * *", Run: func(ctx context.Context) error {
+				runs++
+				if runs <= tt.failures {
+					return errors.New("slack is down")
+				}
+				return nil
+			}})
+			if err != nil {
+				t.Fatalf("Add returned error: %v", err)
+			}
+
+			s.fire(context.Background(), s.jobs[0], jstTime(16, 7, 0))
+			if runs != tt.wantRuns {
+				t.Errorf("runs = %d, want %d", runs, tt.wantRuns)
+			}
+		})
+	}
+}
+
+func TestAddRejectsInvalidSpec(t *testing.T) {
+	s := New(&memoryStateStore{state: map[string]time.Time{}}, time.Hour)
+	if err := s.Add(Job{Name: "bad", Spec: "every morning"}); err == nil {
+		t.Error("Add accepted an invalid cron expression")
+	}
+}
+
+func ptr(t time.Time) *time.Time {
+	return &t
+}
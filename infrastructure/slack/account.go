@@ -0,0 +1,169 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/usecase"
+	"github.com/monchh/annict-slack-bot/usecase/annictcmd"
+
+	"github.com/slack-go/slack"
+)
+
+// servicesFor builds the use cases bound to the Annict account linked by the Slack user.
+// It returns usecase.ErrAccountNotLinked when the user has not linked an account.
+// While linking is disabled, everyone is served by the default account.
+func (b *Bot) servicesFor(ctx context.Context, slackUserID string) (*UserServices, error) {
+	if !b.accounts.LinkingEnabled() {
+		return b.defaultServices, nil
+	}
+	token, err := b.accounts.Token(ctx, slackUserID)
+	if err != nil {
+		return nil, err
+	}
+	return b.newServices(token), nil
+}
+
+// catalogServicesFor is servicesFor for catalog lookups that show nothing personal, i.e.
+// work search and the season lineup. Users who have not linked an account are served by the
+// default account instead. Everything else shows the user's own schedule, library or settings
+// and requires linking, so the owner's data is never posted on someone else's behalf.
+func (b *Bot) catalogServicesFor(ctx context.Context, slackUserID string) (*UserServices, error) {
+	services, err := b.servicesFor(ctx, slackUserID)
+	if errors.Is(err, usecase.ErrAccountNotLinked) {
+		return b.defaultServices, nil
+	}
+	return services, err
+}
+
+// postInteractionError reports an error of a button or menu action to the acting user only.
+func (b *Bot) postInteractionError(ctx context.Context, callback slack.InteractionCallback, err error) {
+	if errors.Is(err, usecase.ErrAccountNotLinked) {
+		b.postInteractionEphemeral(ctx, callback, b.presenter.FormatAccountNotLinked())
+		return
+	}
+	slog.Info(fmt.Sprintf("Error handling interaction from user %s: %v", callback.User.ID, err))
+	b.postInteractionEphemeral(ctx, callback, b.presenter.FormatError(err))
+}
+
+// handleLinkCommand DMs the calling user an Annict authorize URL. Without OAuth
+// credentials it falls back to a button that opens the personal access token modal.
+// Either way, nothing secret is typed into the channel itself.
+func (b *Bot) handleLinkCommand(ctx context.Context, req *annictcmd.Request) error {
+	slog.Info(fmt.Sprintf("Received command: '%s' from user %s", req.Command.Name, req.UserID))
+	if !b.accounts.LinkingEnabled() {
+		b.replyEphemeralText(ctx, req, b.presenter.FormatLinkingDisabled())
+		return nil
+	}
+	if b.accounts.OAuthEnabled() {
+		authorizeURL, err := b.accounts.StartLink(ctx, req.UserID)
+		if err != nil {
+			return fmt.Errorf("連携 URL の発行エラー: %w", err)
+		}
+		if err := b.postDirectMessage(ctx, req.UserID, "Annict アカウントを連携する", b.presenter.FormatAuthorizeLink(authorizeURL)); err != nil {
+			return err
+		}
+		b.replyEphemeralText(ctx, req, b.presenter.FormatAuthorizeLinkSent())
+		return nil
+	}
+	b.replyEphemeral(ctx, req, "Annict アカウントを連携する", b.presenter.FormatLinkPrompt())
+	return nil
+}
+
+// handleUnlinkCommand forgets the calling user's Annict token.
+func (b *Bot) handleUnlinkCommand(ctx context.Context, req *annictcmd.Request) error {
+	slog.Info(fmt.Sprintf("Received command: '%s' from user %s", req.Command.Name, req.UserID))
+	if !b.accounts.LinkingEnabled() {
+		b.replyEphemeralText(ctx, req, b.presenter.FormatLinkingDisabled())
+		return nil
+	}
+	if err := b.accounts.Unlink(ctx, req.UserID); err != nil {
+		return err
+	}
+	b.replyEphemeralText(ctx, req, b.presenter.FormatAccountUnlinked())
+	b.refreshHome(ctx, req.UserID)
+	return nil
+}
+
+// openLinkModal opens the token modal for the user who clicked the link button.
+func (b *Bot) openLinkModal(ctx context.Context, callback slack.InteractionCallback) {
+	_, err := b.slackClient.OpenViewContext(ctx, callback.TriggerID, b.presenter.FormatLinkModal(callback.Channel.ID))
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error opening link modal for user %s: %v", callback.User.ID, err))
+	}
+}
+
+// handleViewSubmission processes submitted modals.
+// A non-nil response keeps the modal open and shows it to the user, e.g. validation errors.
+func (b *Bot) handleViewSubmission(ctx context.Context, callback slack.InteractionCallback) *slack.ViewSubmissionResponse {
+	switch callback.View.CallbackID {
+	case annictcmd.VIEW_LINK_ACCOUNT:
+		return b.handleLinkSubmission(ctx, callback)
+	case annictcmd.VIEW_CHANNEL_PREFERENCES:
+		return b.handleChannelPreferencesSubmission(ctx, callback)
+	default:
+		slog.Debug(fmt.Sprintf("Skipped view submission: %s", callback.View.CallbackID))
+		return nil
+	}
+}
+
+// handleLinkSubmission closes the link modal right away and verifies and stores the
+// entered token in the background, since Annict may not answer within Slack's 3-second
+// deadline for view submissions. The result is reported to the user afterwards.
+func (b *Bot) handleLinkSubmission(ctx context.Context, callback slack.InteractionCallback) *slack.ViewSubmissionResponse {
+	var token string
+	if callback.View.State != nil {
+		token = callback.View.State.Values[annictcmd.BLOCK_LINK_TOKEN][annictcmd.ACTION_LINK_TOKEN_INPUT].Value
+	}
+	if strings.TrimSpace(token) == "" {
+		return slack.NewErrorsViewSubmissionResponse(map[string]string{
+			annictcmd.BLOCK_LINK_TOKEN: "Annict で発行したトークンを入力してください。",
+		})
+	}
+
+	go b.linkAccount(ctx, callback.User.ID, callback.View.PrivateMetadata, token)
+	return slack.NewClearViewSubmissionResponse()
+}
+
+// linkAccount verifies and stores the token submitted in the link modal and reports the
+// result in the channel the modal was opened from, or in a DM when there is none.
+func (b *Bot) linkAccount(ctx context.Context, slackUserID, channelID, token string) {
+	viewer, err := b.accounts.Link(ctx, slackUserID, token)
+	var text string
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error linking Annict account for user %s: %v", slackUserID, err))
+		text = b.presenter.FormatLinkFailed()
+	} else {
+		slog.Info(fmt.Sprintf("Linked Slack user %s to Annict user %s", slackUserID, viewer.Username))
+		text = b.presenter.FormatAccountLinked(viewer)
+		b.refreshHome(ctx, slackUserID)
+	}
+
+	if channelID != "" {
+		b.postEphemeralMessage(ctx, channelID, slackUserID, "", text)
+		return
+	}
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil),
+	}
+	if err := b.postDirectMessage(ctx, slackUserID, text, blocks); err != nil {
+		slog.Info(fmt.Sprintf("Error reporting the linked account to user %s: %v", slackUserID, err))
+	}
+}
+
+// NotifyAccountLinked tells the user in a DM that the OAuth flow completed.
+func (b *Bot) NotifyAccountLinked(ctx context.Context, slackUserID string, viewer *entity.Viewer) {
+	text := b.presenter.FormatAccountLinked(viewer)
+	blocks := []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil),
+	}
+	if err := b.postDirectMessage(ctx, slackUserID, text, blocks); err != nil {
+		slog.Info(fmt.Sprintf("Error notifying user %s of the linked account: %v", slackUserID, err))
+	}
+	// Synchronous: ctx belongs to the OAuth callback request and ends with it.
+	b.publishHome(ctx, slackUserID)
+}
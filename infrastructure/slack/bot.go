@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log"
 	"log/slog"
+	"regexp"
 	"strings"
 	"time"
 
@@ -12,36 +13,176 @@ import (
 	"github.com/monchh/annict-slack-bot/usecase"
 	"github.com/monchh/annict-slack-bot/usecase/annictcmd"
 
-	"github.com/monchh/annict-slack-bot/pkg/jst"
 	"github.com/slack-go/slack"
 	"github.com/slack-go/slack/slackevents"
 	"github.com/slack-go/slack/socketmode"
 )
 
+// mentionPattern matches user mentions such as "<@U012AB3CD>".
+var mentionPattern = regexp.MustCompile(`<@[A-Z0-9]+>`)
+
 // Bot handles Slack interactions and orchestrates the use case execution.
 type Bot struct {
-	slackClient      *slack.Client
-	socketClient     *socketmode.Client
-	annictInfoGetter AnnictInfoGetter
-	presenter        ProgramPresenter
-	botUserID        string
+	slackClient     *slack.Client
+	socketClient    *socketmode.Client
+	accounts        AccountLinker
+	subscriptions   StreamingSubscriptions
+	channelPrefs    ChannelPreferenceSettings
+	digests         DailyDigestLog
+	newServices     UserServicesFactory
+	defaultServices *UserServices
+	presenter       ProgramPresenter
+	commands        *annictcmd.Registry
+	botUserID       string
+}
+
+// UserServices bundles the use cases bound to a single Annict account.
+type UserServices struct {
+	AnnictInfoGetter AnnictInfoGetter
+	WeeklySchedule   WeeklyScheduleGetter
+	EpisodeRecorder  EpisodeRecorder
+	StatusUpdater    StatusUpdater
+	Channels         ChannelLister
+	WorkSearcher     WorkSearcher
+	SeasonLineup     SeasonLineupGetter
+	SeasonPreview    SeasonPreviewGetter
+	WatchStats       WatchStatsGetter
+}
+
+// UserServicesFactory builds the use cases for the given Annict access token.
+type UserServicesFactory func(token string) *UserServices
+
+// AccountLinker defines the methods needed from the account link use case.
+type AccountLinker interface {
+	LinkingEnabled() bool
+	OAuthEnabled() bool
+	StartLink(ctx context.Context, slackUserID string) (string, error)
+	Link(ctx context.Context, slackUserID, token string) (*entity.Viewer, error)
+	Unlink(ctx context.Context, slackUserID string) error
+	Token(ctx context.Context, slackUserID string) (string, error)
+}
+
+// StreamingSubscriptions defines the methods needed from the streaming subscription use case.
+type StreamingSubscriptions interface {
+	Subscriptions(ctx context.Context, slackUserID string) ([]string, error)
+	Subscribe(ctx context.Context, slackUserID string, names []string) ([]string, error)
+	Unsubscribe(ctx context.Context, slackUserID string) error
+	KnownServices(ctx context.Context) ([]string, error)
+}
+
+// ChannelPreferenceSettings defines the methods needed from the channel preference use case.
+type ChannelPreferenceSettings interface {
+	Preferences(ctx context.Context, slackUserID string) (*entity.ChannelPreferences, error)
+	Save(ctx context.Context, slackUserID string, prefs *entity.ChannelPreferences) error
+}
+
+// DailyDigestLog defines the methods needed from the daily digest log use case.
+type DailyDigestLog interface {
+	Find(ctx context.Context, channelID string, now time.Time) (string, error)
+	Record(ctx context.Context, channelID, messageTS string, now time.Time) error
+	MarkExpanded(ctx context.Context, channelID, messageTS string, now time.Time) error
+	Expanded(ctx context.Context, channelID, messageTS string, now time.Time) (bool, error)
+}
+
+// ChannelLister defines the methods needed from the channel list use case.
+type ChannelLister interface {
+	Execute(ctx context.Context) ([]entity.Channel, error)
+	Known(ctx context.Context) []entity.Channel
+	Resolve(ctx context.Context, names []string) ([]entity.Channel, error)
+}
+
+// WorkSearcher defines the method needed from the work search use case.
+type WorkSearcher interface {
+	Execute(ctx context.Context, query *usecase.WorkSearchQuery) (*usecase.WorkSearchPage, error)
+}
+
+// SeasonLineupGetter defines the method needed from the season lineup use case.
+type SeasonLineupGetter interface {
+	Execute(ctx context.Context, input *usecase.SeasonLineupInput) (*usecase.SeasonLineupOutput, error)
+}
+
+// SeasonPreviewGetter defines the methods needed from the season preview use case.
+type SeasonPreviewGetter interface {
+	Due(ctx context.Context, now time.Time) (bool, error)
+	MarkPosted(ctx context.Context, channelID, messageTS string, now time.Time) error
+	Execute(ctx context.Context) (*usecase.SeasonPreviewOutput, error)
+}
+
+// WatchStatsGetter defines the method needed from the watch statistics use case.
+type WatchStatsGetter interface {
+	Execute(ctx context.Context) (*entity.WatchStats, error)
 }
 
 // AnnictInfoGetter defines the method needed from the domain use case.
 type AnnictInfoGetter interface {
-	Execute(ctx context.Context) (*usecase.AnnictInfoGetterOutput, error)
+	Execute(ctx context.Context, input *usecase.AnnictInfoGetterInput) (*usecase.AnnictInfoGetterOutput, error)
+}
+
+// WeeklyScheduleGetter defines the method needed from the weekly schedule use case.
+type WeeklyScheduleGetter interface {
+	Execute(ctx context.Context, input *usecase.WeeklyScheduleInput) (*usecase.WeeklyScheduleOutput, error)
+}
+
+// EpisodeRecorder defines the method needed from the record use case.
+type EpisodeRecorder interface {
+	Execute(ctx context.Context, episodeID string) error
+}
+
+// StatusUpdater defines the methods needed from the status use case.
+type StatusUpdater interface {
+	Execute(ctx context.Context, workID string, status entity.WatchStatus) (*entity.Work, error)
+	ExecuteByTitle(ctx context.Context, title string, status entity.WatchStatus) (*entity.Work, error)
 }
 
 // ProgramPresenter defines the methods needed from the presenter.
 type ProgramPresenter interface {
-	FormatCombinedPrograms(todaysPrograms []*entity.Program, unwatchedPrograms []*entity.Program, date time.Time) []slack.Block
+	FormatCombinedPrograms(todaysPrograms []*entity.Program, unwatchedPrograms []*entity.Program, date time.Time, page annictcmd.ProgramsPage) []slack.Block
+	FormatMorePrograms(todaysPrograms []*entity.Program, date time.Time, page annictcmd.ProgramsPage) [][]slack.Block
+	MarkProgramsExpanded(blocks []slack.Block) []slack.Block
+	FormatProgramsPageExpired() string
+	MarkEpisodeRecorded(blocks []slack.Block, episodeID string) []slack.Block
+	FormatStatusUpdated(work *entity.Work) string
+	FormatReminder(program *entity.Program) string
+	FormatWeeklySummary(days []*usecase.DaySchedule) []slack.Block
+	FormatWeeklySchedule(days []*usecase.DaySchedule) []slack.Block
+	FormatDaySchedule(day *usecase.DaySchedule) []slack.Block
+	FormatHelp(commands []*annictcmd.Command) string
+	FormatUnknownCommand(err *annictcmd.UnknownCommandError) string
+	FormatUsageError(err *annictcmd.UsageError) string
+	FormatAuthorizeLink(authorizeURL string) []slack.Block
+	FormatAuthorizeLinkSent() string
+	FormatLinkPrompt() []slack.Block
+	FormatLinkModal(channelID string) slack.ModalViewRequest
+	FormatAccountLinked(viewer *entity.Viewer) string
+	FormatLinkFailed() string
+	FormatAccountUnlinked() string
+	FormatAccountNotLinked() string
+	FormatLinkingDisabled() string
+	FormatStreamingSubscriptions(subscribed, known []string) string
+	FormatStreamingNotConfigured() string
+	FormatChannelPreferences(prefs *entity.ChannelPreferences) []slack.Block
+	FormatChannelPreferencesSaved(prefs *entity.ChannelPreferences) string
+	FormatChannelPreferencesModal(prefs *entity.ChannelPreferences, channelID string) slack.ModalViewRequest
+	FormatChannelOptions(channels []entity.Channel) []*slack.OptionGroupBlockObject
+	FormatSearchResults(query *usecase.WorkSearchQuery, works []*entity.Work) []slack.Block
+	FormatSeasonLineup(lineup *usecase.SeasonLineupOutput, cursors []string, slackUserID string) []slack.Block
+	FormatSeasonPreview(preview *usecase.SeasonPreviewOutput) []slack.Block
+	FormatHome(info *usecase.AnnictInfoGetterOutput, stats *entity.WatchStats, date time.Time) slack.HomeTabViewRequest
+	FormatHomeNotLinked() slack.HomeTabViewRequest
 	FormatError(err error) string
 }
 
 // NewBot creates a new Slack Bot instance.
+// Commands run with the Annict account linked by the calling Slack user;
+// defaultServices is used for posts nobody requested, such as the daily digest.
 func NewBot(
 	slackBotToken, slackAppToken string,
-	annictInfoGetter AnnictInfoGetter,
+	accounts AccountLinker,
+	subscriptions StreamingSubscriptions,
+	channelPrefs ChannelPreferenceSettings,
+	digests DailyDigestLog,
+	newServices UserServicesFactory,
+	defaultServices *UserServices,
 	presenter ProgramPresenter,
 	debug bool,
 ) (*Bot, error) {
@@ -70,13 +211,21 @@ func NewBot(
 	}
 	socketClient := socketmode.New(apiClient, socketClientOpts...)
 
-	return &Bot{
-		slackClient:      apiClient,
-		socketClient:     socketClient,
-		annictInfoGetter: annictInfoGetter,
-		presenter:        presenter,
-		botUserID:        botUserID,
-	}, nil
+	bot := &Bot{
+		slackClient:     apiClient,
+		socketClient:    socketClient,
+		accounts:        accounts,
+		subscriptions:   subscriptions,
+		channelPrefs:    channelPrefs,
+		digests:         digests,
+		newServices:     newServices,
+		defaultServices: defaultServices,
+		presenter:       presenter,
+		commands:        annictcmd.NewRegistry(),
+		botUserID:       botUserID,
+	}
+	bot.registerCommands()
+	return bot, nil
 }
 
 // Run starts the bot's event loop.
@@ -128,6 +277,41 @@ func (b *Bot) processEvent(ctx context.Context, socketEvent socketmode.Event) {
 		}
 		b.socketClient.Ack(*socketEvent.Request)
 		b.handleEventsAPI(ctx, eventsAPIEvent)
+	case socketmode.EventTypeSlashCommand:
+		slog.Info("Socket Mode client slash command.")
+		command, ok := socketEvent.Data.(slack.SlashCommand)
+		if !ok {
+			slog.Warn(fmt.Sprintf("Ignored unexpected SlashCommand data type: %T", socketEvent.Data))
+			return
+		}
+		// Acknowledge within Slack's 3 seconds; the reply follows through the response_url
+		// once Annict has answered. Handled in the background so a slow Annict request
+		// does not hold up the events behind it.
+		b.socketClient.Ack(*socketEvent.Request)
+		go b.handleSlashCommand(ctx, command)
+	case socketmode.EventTypeInteractive:
+		slog.Info("Socket Mode client interactive.")
+		callback, ok := socketEvent.Data.(slack.InteractionCallback)
+		if !ok {
+			slog.Warn(fmt.Sprintf("Ignored unexpected Interactive data type: %T", socketEvent.Data))
+			return
+		}
+		if callback.Type == slack.InteractionTypeViewSubmission {
+			// View submissions are acknowledged with the response, e.g. validation errors.
+			if response := b.handleViewSubmission(ctx, callback); response != nil {
+				b.socketClient.Ack(*socketEvent.Request, response)
+			} else {
+				b.socketClient.Ack(*socketEvent.Request)
+			}
+			return
+		}
+		if callback.Type == slack.InteractionTypeBlockSuggestion {
+			// External selects load their options from the acknowledgement.
+			b.socketClient.Ack(*socketEvent.Request, b.handleBlockSuggestion(ctx, callback))
+			return
+		}
+		b.socketClient.Ack(*socketEvent.Request)
+		go b.handleInteraction(ctx, callback)
 	default:
 		slog.Debug(fmt.Sprintf("Skipped event type: %s", socketEvent.Type))
 	}
@@ -141,6 +325,14 @@ func (b *Bot) handleEventsAPI(ctx context.Context, event slackevents.EventsAPIEv
 		switch ev := innerEvent.Data.(type) {
 		case *slackevents.AppMentionEvent:
 			b.handleAppMention(ctx, ev)
+		case *slackevents.AppHomeOpenedEvent:
+			if ev.Tab == "home" {
+				b.refreshHome(ctx, ev.User)
+			}
+		case *slackevents.MessageEvent:
+			if ev.ChannelType == slack.TYPE_IM {
+				b.handleDirectMessage(ctx, ev)
+			}
 		default: // Ignore other callback events
 		}
 	default: // Ignore other Events API types
@@ -154,57 +346,186 @@ func (b *Bot) handleAppMention(ctx context.Context, event *slackevents.AppMentio
 		return // Ignore self
 	}
 
-	textContent := strings.ToLower(strings.TrimSpace(event.Text))
-	if strings.Contains(textContent, annictcmd.ANNICT_TODAY) {
-		slog.Info(fmt.Sprintf("Received command: '%s'", annictcmd.ANNICT_TODAY))
+	// Reply in the thread of the mention so repeated commands do not flood the channel.
+	threadTS := event.ThreadTimeStamp
+	if threadTS == "" {
+		threadTS = event.TimeStamp
+	}
+	commandText := strings.TrimSpace(mentionPattern.ReplaceAllString(event.Text, ""))
+	b.dispatchCommand(ctx, commandText, annictcmd.Origin{UserID: event.User, ChannelID: event.Channel, ThreadTS: threadTS})
+}
 
-		// Execute both use cases
-		var todayPrograms []*entity.Program
-		var libraryEntries []*entity.Program
-		var combinedErr error
+// handleDirectMessage processes messages sent to the bot in a DM. The whole text is the
+// command, with or without a mention, and replies stay in the DM where only the user sees them.
+func (b *Bot) handleDirectMessage(ctx context.Context, event *slackevents.MessageEvent) {
+	// Edits, deletions and bot messages, including the bot's own replies, carry a subtype
+	// or a bot ID. Reacting to them would make the bot answer itself.
+	if event.SubType != "" || event.BotID != "" || event.User == "" || event.User == b.botUserID {
+		slog.Debug(fmt.Sprintf("Skipped direct message in %s: subtype=%q bot=%q user=%q", event.Channel, event.SubType, event.BotID, event.User))
+		return
+	}
+	slog.Info(fmt.Sprintf("Received direct message from user %s in channel %s with text: %q", event.User, event.Channel, event.Text))
 
-		// Fetch Annict
-		annictInfo, err := b.annictInfoGetter.Execute(ctx)
-		if err != nil {
-			slog.Info(fmt.Sprintf("Error fetching today's programs: %v", err))
-			combinedErr = fmt.Errorf("annictからの情報取得エラー: %w", err)
-		} else if annictInfo != nil {
-			todayPrograms = annictInfo.Programs
-			libraryEntries = annictInfo.LibraryEntries
-		}
+	// A DM is private already, so replies only go to a thread the user started.
+	commandText := strings.TrimSpace(mentionPattern.ReplaceAllString(event.Text, ""))
+	b.dispatchCommand(ctx, commandText, annictcmd.Origin{UserID: event.User, ChannelID: event.Channel, ThreadTS: event.ThreadTimeStamp})
+}
 
-		// Present the results
-		if combinedErr != nil && len(libraryEntries) == 0 && len(todayPrograms) == 0 {
-			errorMsg := b.presenter.FormatError(combinedErr)
-			b.postTextMessage(ctx, event.Channel, errorMsg)
-			return
+// handleSlashCommand processes "/annict <command>", which works in any channel
+// without inviting the bot. The text is the same as after a mention.
+func (b *Bot) handleSlashCommand(ctx context.Context, command slack.SlashCommand) {
+	slog.Info(fmt.Sprintf("Received slash command %s from user %s in channel %s with text: %q", command.Command, command.UserID, command.ChannelID, command.Text))
+	origin := annictcmd.Origin{UserID: command.UserID, ChannelID: command.ChannelID, ResponseURL: command.ResponseURL}
+	b.dispatchCommand(ctx, strings.TrimSpace(command.Text), origin)
+}
+
+// handleInteraction processes interactive payloads such as button clicks.
+func (b *Bot) handleInteraction(ctx context.Context, callback slack.InteractionCallback) {
+	switch callback.Type {
+	case slack.InteractionTypeBlockActions:
+		for _, action := range callback.ActionCallback.BlockActions {
+			switch action.ActionID {
+			case annictcmd.ACTION_RECORD_EPISODE:
+				b.handleRecordEpisode(ctx, callback, action)
+			case annictcmd.ACTION_UPDATE_STATUS:
+				b.handleUpdateStatus(ctx, callback, action)
+			case annictcmd.ACTION_ADD_WANNA_WATCH:
+				b.handleAddWannaWatch(ctx, callback, action)
+			case annictcmd.ACTION_SEASON_PREV_PAGE, annictcmd.ACTION_SEASON_NEXT_PAGE:
+				b.handleSeasonPage(ctx, callback, action)
+			case annictcmd.ACTION_SHOW_MORE_PROGRAMS:
+				b.handleShowMorePrograms(ctx, callback, action)
+			case annictcmd.ACTION_OPEN_LINK:
+				b.openLinkModal(ctx, callback)
+			case annictcmd.ACTION_OPEN_CHANNEL_PREFERENCES:
+				b.openChannelPreferencesModal(ctx, callback)
+			default:
+				slog.Debug(fmt.Sprintf("Skipped block action: %s", action.ActionID))
+			}
 		}
+	default: // Ignore other interaction types
+	}
+}
 
-		// Use the updated presenter method
-		blocks := b.presenter.FormatCombinedPrograms(todayPrograms, libraryEntries, jst.Now())
-		fallbackText := fmt.Sprintf("%s のアニメ情報 + 未視聴", jst.FormatDate(jst.Now()))
-		b.postBlockMessage(ctx, event.Channel, fallbackText, blocks)
+// handleRecordEpisode records the episode and updates the original message in place.
+func (b *Bot) handleRecordEpisode(ctx context.Context, callback slack.InteractionCallback, action *slack.BlockAction) {
+	episodeID := action.Value
+	slog.Info(fmt.Sprintf("Received record request from user %s for episode %s", callback.User.ID, episodeID))
 
-		if combinedErr != nil {
-			// Optionally log or notify about partial errors
-			slog.Info(fmt.Sprintf("Partial error occurred during fetch: %v", combinedErr))
-		}
-	} else {
-		slog.Info(fmt.Sprintf("Receive unknown command: %s", textContent))
-		b.postTextMessage(ctx, event.Channel, fmt.Sprintf("Receive unknown command: %s", textContent))
+	services, err := b.servicesFor(ctx, callback.User.ID)
+	if err != nil {
+		b.postInteractionError(ctx, callback, err)
+		return
+	}
+	if err := services.EpisodeRecorder.Execute(ctx, episodeID); err != nil {
+		slog.Info(fmt.Sprintf("Error recording episode %s: %v", episodeID, err))
+		b.postInteractionEphemeral(ctx, callback, b.presenter.FormatError(fmt.Errorf("視聴記録エラー: %w", err)))
+		return
+	}
+	b.refreshHome(ctx, callback.User.ID)
+
+	if len(callback.Message.Blocks.BlockSet) == 0 {
+		slog.Debug(fmt.Sprintf("Recorded episode %s, but the clicked message came without blocks to update", episodeID))
+		return
+	}
+	blocks := b.presenter.MarkEpisodeRecorded(callback.Message.Blocks.BlockSet, episodeID)
+	b.updateInteractionMessage(ctx, callback, callback.Message.Text, blocks)
+}
+
+// handleUpdateStatus changes the watch status selected from a program's overflow menu.
+func (b *Bot) handleUpdateStatus(ctx context.Context, callback slack.InteractionCallback, action *slack.BlockAction) {
+	workID, rawStatus, err := annictcmd.DecodeStatusActionValue(action.SelectedOption.Value)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("Ignored malformed status action: %v", err))
+		return
+	}
+	status, err := entity.ParseWatchStatus(rawStatus)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("Ignored malformed status action: %v", err))
+		return
+	}
+	slog.Info(fmt.Sprintf("Received status update from user %s for work %s: %s", callback.User.ID, workID, status))
+
+	services, err := b.servicesFor(ctx, callback.User.ID)
+	if err != nil {
+		b.postInteractionError(ctx, callback, err)
+		return
+	}
+	work, err := services.StatusUpdater.Execute(ctx, workID, status)
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error updating status of work %s: %v", workID, err))
+		b.postInteractionEphemeral(ctx, callback, b.presenter.FormatError(fmt.Errorf("ステータス変更エラー: %w", err)))
+		return
+	}
+	b.postInteractionEphemeral(ctx, callback, b.presenter.FormatStatusUpdated(work))
+	b.refreshHome(ctx, callback.User.ID)
+}
+
+// handleAddWannaWatch adds the work of a search result to the acting user's library as WANNA_WATCH.
+func (b *Bot) handleAddWannaWatch(ctx context.Context, callback slack.InteractionCallback, action *slack.BlockAction) {
+	workID := action.Value
+	slog.Info(fmt.Sprintf("Received wanna watch request from user %s for work %s", callback.User.ID, workID))
+
+	services, err := b.servicesFor(ctx, callback.User.ID)
+	if err != nil {
+		b.postInteractionError(ctx, callback, err)
+		return
+	}
+	work, err := services.StatusUpdater.Execute(ctx, workID, entity.WatchStatusWannaWatch)
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error adding work %s to the library: %v", workID, err))
+		b.postInteractionEphemeral(ctx, callback, b.presenter.FormatError(fmt.Errorf("ステータス変更エラー: %w", err)))
+		return
+	}
+	b.postInteractionEphemeral(ctx, callback, b.presenter.FormatStatusUpdated(work))
+	b.refreshHome(ctx, callback.User.ID)
+}
+
+// handleSeasonPage replaces a season lineup message with the requested page,
+// showing the statuses of the user who clicked.
+func (b *Bot) handleSeasonPage(ctx context.Context, callback slack.InteractionCallback, action *slack.BlockAction) {
+	seasons, cursors, err := annictcmd.DecodeSeasonPageValue(action.Value)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("Ignored malformed season page action: %v", err))
+		return
+	}
+	services, err := b.catalogServicesFor(ctx, callback.User.ID)
+	if err != nil {
+		b.postInteractionError(ctx, callback, err)
+		return
+	}
+	input := &usecase.SeasonLineupInput{Seasons: seasons}
+	if len(cursors) > 0 {
+		input.After = cursors[len(cursors)-1]
+	}
+	lineup, err := services.SeasonLineup.Execute(ctx, input)
+	if err != nil {
+		b.postInteractionError(ctx, callback, fmt.Errorf("シーズン作品の取得エラー: %w", err))
+		return
 	}
+	blocks := b.presenter.FormatSeasonLineup(lineup, cursors, callback.User.ID)
+	b.updateInteractionMessage(ctx, callback, callback.Message.Text, blocks)
 }
 
-// postTextMessage and postBlockMessage remain the same
-func (b *Bot) postTextMessage(ctx context.Context, channelID, text string) {
-	_, _, err := b.slackClient.PostMessageContext(ctx, channelID, slack.MsgOptionText(text, false))
+// inThread returns the option posting a message in the thread, none for top-level messages.
+func inThread(threadTS string) []slack.MsgOption {
+	if threadTS == "" {
+		return nil
+	}
+	return []slack.MsgOption{slack.MsgOptionTS(threadTS)}
+}
+
+// postTextMessage posts text in the thread, or top-level when threadTS is empty.
+func (b *Bot) postTextMessage(ctx context.Context, channelID, threadTS, text string) {
+	options := append([]slack.MsgOption{slack.MsgOptionText(text, false)}, inThread(threadTS)...)
+	_, _, err := b.slackClient.PostMessageContext(ctx, channelID, options...)
 	if err != nil {
 		slog.Info(fmt.Sprintf("Error posting text message to channel %s: %v", channelID, err))
 	}
 }
 
-func (b *Bot) postBlockMessage(ctx context.Context, channelID, fallbackText string, blocks []slack.Block) {
-	_, _, err := b.slackClient.PostMessageContext(
+func (b *Bot) postBlockMessage(ctx context.Context, channelID, fallbackText string, blocks []slack.Block) string {
+	_, timestamp, err := b.slackClient.PostMessageContext(
 		ctx,
 		channelID,
 		slack.MsgOptionBlocks(blocks...),
@@ -212,5 +533,83 @@ func (b *Bot) postBlockMessage(ctx context.Context, channelID, fallbackText stri
 	)
 	if err != nil {
 		slog.Info(fmt.Sprintf("Error posting block message to channel %s: %v", channelID, err))
+		return ""
+	}
+	return timestamp
+}
+
+// postThreadBlockMessage posts blocks in the thread, or top-level when threadTS is empty,
+// and returns the timestamp of the new message.
+func (b *Bot) postThreadBlockMessage(ctx context.Context, channelID, threadTS, fallbackText string, blocks []slack.Block) string {
+	if threadTS == "" {
+		return b.postBlockMessage(ctx, channelID, fallbackText, blocks)
 	}
+	_, timestamp, err := b.slackClient.PostMessageContext(
+		ctx,
+		channelID,
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionText(fallbackText, false),
+		slack.MsgOptionTS(threadTS),
+	)
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error posting thread message to channel %s: %v", channelID, err))
+		return ""
+	}
+	return timestamp
+}
+
+// postEphemeralMessage posts text only the user can see, in the thread when threadTS is set.
+func (b *Bot) postEphemeralMessage(ctx context.Context, channelID, userID, threadTS, text string) {
+	options := append([]slack.MsgOption{slack.MsgOptionText(text, false)}, inThread(threadTS)...)
+	_, err := b.slackClient.PostEphemeralContext(ctx, channelID, userID, options...)
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error posting ephemeral message to channel %s: %v", channelID, err))
+	}
+}
+
+// postEphemeralBlockMessage posts blocks only the user can see, in the thread when threadTS is set.
+func (b *Bot) postEphemeralBlockMessage(ctx context.Context, channelID, userID, threadTS, fallbackText string, blocks []slack.Block) {
+	options := append([]slack.MsgOption{
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionText(fallbackText, false),
+	}, inThread(threadTS)...)
+	_, err := b.slackClient.PostEphemeralContext(ctx, channelID, userID, options...)
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error posting ephemeral block message to channel %s: %v", channelID, err))
+	}
+}
+
+// postDirectMessage posts blocks to the DM between the bot and the user.
+func (b *Bot) postDirectMessage(ctx context.Context, userID, fallbackText string, blocks []slack.Block) error {
+	channel, _, _, err := b.slackClient.OpenConversationContext(ctx, &slack.OpenConversationParameters{
+		Users:    []string{userID},
+		ReturnIM: true,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to open DM with %s: %w", userID, err)
+	}
+	_, _, err = b.slackClient.PostMessageContext(
+		ctx,
+		channel.ID,
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionText(fallbackText, false),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to post DM to %s: %w", userID, err)
+	}
+	return nil
+}
+
+func (b *Bot) updateBlockMessage(ctx context.Context, channelID, timestamp, fallbackText string, blocks []slack.Block) error {
+	_, _, _, err := b.slackClient.UpdateMessageContext(
+		ctx,
+		channelID,
+		timestamp,
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionText(fallbackText, false),
+	)
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error updating block message %s in channel %s: %v", timestamp, channelID, err))
+	}
+	return err
 }
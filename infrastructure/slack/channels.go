@@ -0,0 +1,182 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/usecase"
+	"github.com/monchh/annict-slack-bot/usecase/annictcmd"
+
+	"github.com/slack-go/slack"
+)
+
+// maxSuggestedOptions is the number of options Slack accepts in a block_suggestion response.
+const maxSuggestedOptions = 100
+
+// channelPreferencesFor returns the user's channel preferences, or nil (every channel)
+// when they cannot be read.
+func (b *Bot) channelPreferencesFor(ctx context.Context, slackUserID string) *entity.ChannelPreferences {
+	prefs, err := b.channelPrefs.Preferences(ctx, slackUserID)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("Failed to read channel preferences of %s: %v", slackUserID, err))
+		return nil
+	}
+	return prefs
+}
+
+// handleChannelsCommand shows or edits the calling user's channel preferences from
+// "annict channels [allow|prefer|clear] [name, ...]". Without arguments it posts the
+// current preferences with a button that opens the edit modal.
+func (b *Bot) handleChannelsCommand(ctx context.Context, req *annictcmd.Request) error {
+	slog.Info(fmt.Sprintf("Received command: '%s' from user %s", req.Command.Name, req.UserID))
+	prefs, err := b.channelPrefs.Preferences(ctx, req.UserID)
+	if err != nil {
+		return fmt.Errorf("チャンネル設定の取得エラー: %w", err)
+	}
+
+	action := strings.ToLower(req.Arg("action"))
+	switch action {
+	case "":
+		b.replyEphemeral(ctx, req, "チャンネル設定", b.presenter.FormatChannelPreferences(prefs))
+		return nil
+	case annictcmd.CHANNELS_CLEAR:
+		prefs = &entity.ChannelPreferences{}
+	case annictcmd.CHANNELS_ALLOW, annictcmd.CHANNELS_PREFER:
+		names := strings.FieldsFunc(req.Arg("channels"), func(r rune) bool { return r == ',' || r == '、' })
+		services, err := b.servicesFor(ctx, req.UserID)
+		if err != nil {
+			return err
+		}
+		channels, err := services.Channels.Resolve(ctx, names)
+		var unknownErr *usecase.UnknownChannelError
+		if errors.As(err, &unknownErr) {
+			reason := fmt.Sprintf("不明なチャンネルです: %s", unknownErr.Name)
+			if len(unknownErr.Suggestions) > 0 {
+				reason += fmt.Sprintf(" (候補: %s)", strings.Join(unknownErr.Suggestions, " / "))
+			}
+			return &annictcmd.UsageError{Command: req.Command, Reason: reason}
+		}
+		if err != nil {
+			return fmt.Errorf("チャンネルの取得エラー: %w", err)
+		}
+		if action == annictcmd.CHANNELS_ALLOW {
+			prefs.Allowed = channels
+		} else {
+			prefs.Priority = channels
+		}
+	default:
+		return &annictcmd.UsageError{Command: req.Command, Reason: fmt.Sprintf("unknown action %q", action)}
+	}
+
+	if err := b.channelPrefs.Save(ctx, req.UserID, prefs); err != nil {
+		return fmt.Errorf("チャンネル設定の保存エラー: %w", err)
+	}
+	b.replyEphemeralText(ctx, req, b.presenter.FormatChannelPreferencesSaved(prefs))
+	return nil
+}
+
+// openChannelPreferencesModal opens the channel preferences modal for the user who clicked the button.
+func (b *Bot) openChannelPreferencesModal(ctx context.Context, callback slack.InteractionCallback) {
+	prefs, err := b.channelPrefs.Preferences(ctx, callback.User.ID)
+	if err != nil {
+		b.postInteractionError(ctx, callback, fmt.Errorf("チャンネル設定の取得エラー: %w", err))
+		return
+	}
+	_, err = b.slackClient.OpenViewContext(ctx, callback.TriggerID, b.presenter.FormatChannelPreferencesModal(prefs, callback.Channel.ID))
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error opening channel preferences modal for user %s: %v", callback.User.ID, err))
+		return
+	}
+	b.refreshChannels(ctx, callback.User.ID)
+}
+
+// refreshChannels lists the user's channels on Annict in the background, so the
+// suggestions of the preferences modal are up to date by the time the user types.
+func (b *Bot) refreshChannels(ctx context.Context, slackUserID string) {
+	go func() {
+		services, err := b.servicesFor(ctx, slackUserID)
+		if err != nil {
+			slog.Info(fmt.Sprintf("Cannot list channels for user %s: %v", slackUserID, err))
+			return
+		}
+		if _, err := services.Channels.Execute(ctx); err != nil {
+			slog.Info(fmt.Sprintf("Error listing channels for user %s: %v", slackUserID, err))
+		}
+	}()
+}
+
+// handleBlockSuggestion returns the options of the external selects in the channel
+// preferences modal, i.e. the user's known channels whose name contains the typed text.
+// It answers within the ack, so it never calls Annict; the list is refreshed when the
+// modal opens, or in the background when nothing is known yet.
+func (b *Bot) handleBlockSuggestion(ctx context.Context, callback slack.InteractionCallback) *slack.OptionGroupsResponse {
+	response := &slack.OptionGroupsResponse{}
+	if callback.ActionID != annictcmd.ACTION_CHANNEL_ALLOWED_SELECT && callback.ActionID != annictcmd.ACTION_CHANNEL_PRIORITY_SELECT {
+		slog.Debug(fmt.Sprintf("Skipped block suggestion: %s", callback.ActionID))
+		return response
+	}
+	services, err := b.servicesFor(ctx, callback.User.ID)
+	if err != nil {
+		slog.Info(fmt.Sprintf("Cannot suggest channels to user %s: %v", callback.User.ID, err))
+		return response
+	}
+	channels := services.Channels.Known(ctx)
+	if len(channels) == 0 {
+		b.refreshChannels(ctx, callback.User.ID)
+		return response
+	}
+
+	query := strings.ToLower(strings.TrimSpace(callback.Value))
+	var matched []entity.Channel
+	for _, ch := range channels {
+		if query == "" || strings.Contains(strings.ToLower(ch.Name), query) || strings.Contains(strings.ToLower(ch.GroupName), query) {
+			matched = append(matched, ch)
+		}
+		if len(matched) == maxSuggestedOptions {
+			break
+		}
+	}
+	response.OptionGroups = b.presenter.FormatChannelOptions(matched)
+	return response
+}
+
+// handleChannelPreferencesSubmission stores the channels selected in the preferences modal.
+func (b *Bot) handleChannelPreferencesSubmission(ctx context.Context, callback slack.InteractionCallback) *slack.ViewSubmissionResponse {
+	prefs := &entity.ChannelPreferences{}
+	if callback.View.State != nil {
+		values := callback.View.State.Values
+		prefs.Allowed = channelsFromOptions(values[annictcmd.BLOCK_CHANNEL_ALLOWED][annictcmd.ACTION_CHANNEL_ALLOWED_SELECT].SelectedOptions)
+		prefs.Priority = channelsFromOptions(values[annictcmd.BLOCK_CHANNEL_PRIORITY][annictcmd.ACTION_CHANNEL_PRIORITY_SELECT].SelectedOptions)
+	}
+
+	if err := b.channelPrefs.Save(ctx, callback.User.ID, prefs); err != nil {
+		slog.Info(fmt.Sprintf("Error saving channel preferences for user %s: %v", callback.User.ID, err))
+		return slack.NewErrorsViewSubmissionResponse(map[string]string{
+			annictcmd.BLOCK_CHANNEL_ALLOWED: "設定を保存できませんでした。時間をおいて再度お試しください。",
+		})
+	}
+	slog.Info(fmt.Sprintf("Saved channel preferences for user %s", callback.User.ID))
+
+	if channelID := callback.View.PrivateMetadata; channelID != "" {
+		b.postEphemeralMessage(ctx, channelID, callback.User.ID, "", b.presenter.FormatChannelPreferencesSaved(prefs))
+	}
+	return nil
+}
+
+// channelsFromOptions decodes the selected options, skipping malformed values.
+func channelsFromOptions(options []slack.OptionBlockObject) []entity.Channel {
+	var channels []entity.Channel
+	for _, option := range options {
+		annictID, name, err := annictcmd.DecodeChannelOptionValue(option.Value)
+		if err != nil {
+			slog.Warn(fmt.Sprintf("Ignored malformed channel option: %v", err))
+			continue
+		}
+		channels = append(channels, entity.Channel{AnnictID: annictID, Name: name})
+	}
+	return channels
+}
@@ -0,0 +1,538 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+	"github.com/monchh/annict-slack-bot/usecase"
+	"github.com/monchh/annict-slack-bot/usecase/annictcmd"
+
+	"github.com/slack-go/slack"
+)
+
+// registerCommands registers every command the bot understands.
+// Adding a command only requires registering its handler here.
+func (b *Bot) registerCommands() {
+	b.commands.Register(&annictcmd.Command{
+		Name: annictcmd.CMD_TODAY,
+		Flags: []annictcmd.Flag{
+			{Name: annictcmd.FLAG_REBROADCAST, Help: "再放送も表示する"},
+		},
+		Help:     "今日の放送予定と未視聴のアニメを表示します。再放送は表示しません。",
+		Personal: true,
+		Handler:  b.handleTodayCommand,
+	})
+	b.commands.Register(&annictcmd.Command{
+		Name: annictcmd.CMD_WEEK,
+		Flags: []annictcmd.Flag{
+			{Name: annictcmd.FLAG_REBROADCAST, Help: "再放送も表示する"},
+		},
+		Help:     "今日から 7 日間の放送予定を日ごとに表示します。再放送は表示しません。",
+		Personal: true,
+		Handler:  b.handleWeekCommand,
+	})
+	b.commands.Register(&annictcmd.Command{
+		Name: annictcmd.CMD_STATUS,
+		Args: []annictcmd.Arg{
+			{Name: "title", Help: "ライブラリ内の作品タイトル (部分一致可)", Required: true, Variadic: true},
+			{Name: "state", Help: "watching / wanna_watch / on_hold / stop_watching", Required: true},
+		},
+		Help:     "作品の視聴ステータスを変更します。",
+		Personal: true,
+		Handler:  b.handleStatusCommand,
+	})
+	b.commands.Register(&annictcmd.Command{
+		Name: annictcmd.CMD_SEARCH,
+		Args: []annictcmd.Arg{
+			{Name: "keyword", Help: "作品タイトル (部分一致)。2026-autumn や 2026秋 のようなシーズンも指定可", Required: true, Variadic: true},
+		},
+		Flags: []annictcmd.Flag{
+			{Name: annictcmd.FLAG_POPULAR, Help: "視聴者数の多い順に並べる (既定は新しいシーズン順)"},
+		},
+		Help:    "Annict の作品を検索します。結果から「見たい」に追加できます。",
+		Handler: b.handleSearchCommand,
+	})
+	b.commands.Register(&annictcmd.Command{
+		Name: annictcmd.CMD_SEASON,
+		Args: []annictcmd.Arg{
+			{Name: "seasons", Help: "2026-autumn や 2026秋 のようなシーズン (複数可)。省略時は今期と来期", Variadic: true},
+		},
+		Help:    "シーズンの作品を視聴者数の多い順に一覧表示します。自分のステータスも表示します。",
+		Handler: b.handleSeasonCommand,
+	})
+	b.commands.Register(&annictcmd.Command{
+		Name:     annictcmd.CMD_PREVIEW,
+		Help:     "来期のシーズンで「見たい」に登録している作品と初回放送日を表示します。",
+		Personal: true,
+		Handler:  b.handlePreviewCommand,
+	})
+	b.commands.Register(&annictcmd.Command{
+		Name: annictcmd.CMD_SERVICES,
+		Args: []annictcmd.Arg{
+			{Name: "services", Help: "契約中の配信サービス (カンマ区切り)。clear で解除", Variadic: true},
+		},
+		Help:     "契約中の配信サービスを表示・登録します。未視聴のアニメはそのサービスで配信中の作品に絞り込みます。",
+		Personal: true,
+		Handler:  b.handleServicesCommand,
+	})
+	b.commands.Register(&annictcmd.Command{
+		Name: annictcmd.CMD_CHANNELS,
+		Args: []annictcmd.Arg{
+			{Name: "action", Help: "allow (表示するチャンネル) / prefer (優先順) / clear (解除)。省略時は編集画面を表示"},
+			{Name: "channels", Help: "チャンネル名 (カンマ区切り、部分一致可)", Variadic: true},
+		},
+		Help:     "表示する放送チャンネルと優先順を設定します。同じ話数が複数のチャンネルで放送される場合は最優先のチャンネルだけを表示します。",
+		Personal: true,
+		Handler:  b.handleChannelsCommand,
+	})
+	b.commands.Register(&annictcmd.Command{
+		Name:     annictcmd.CMD_LINK,
+		Help:     "自分の Annict アカウントを連携します。トークンはモーダルで入力します。",
+		Personal: true,
+		Handler:  b.handleLinkCommand,
+	})
+	b.commands.Register(&annictcmd.Command{
+		Name:     annictcmd.CMD_UNLINK,
+		Help:     "Annict アカウントとの連携を解除します。",
+		Personal: true,
+		Handler:  b.handleUnlinkCommand,
+	})
+	b.commands.Register(&annictcmd.Command{
+		Name:    annictcmd.CMD_HELP,
+		Aliases: []string{"?"},
+		Args: []annictcmd.Arg{
+			{Name: "command", Help: "詳細を表示するコマンド名"},
+		},
+		Help:     "コマンドの一覧と使い方を表示します。",
+		Personal: true,
+		Handler:  b.handleHelpCommand,
+	})
+}
+
+// dispatchCommand runs the command text and reports input errors back to the caller:
+// where the command was sent for mentions and DMs, and only to the caller for slash commands.
+func (b *Bot) dispatchCommand(ctx context.Context, commandText string, origin annictcmd.Origin) {
+	err := b.commands.Dispatch(ctx, commandText, origin)
+	if err == nil {
+		return
+	}
+
+	req := &annictcmd.Request{Origin: origin}
+	var unknownErr *annictcmd.UnknownCommandError
+	var usageErr *annictcmd.UsageError
+	switch {
+	case errors.Is(err, usecase.ErrAccountNotLinked):
+		slog.Info(fmt.Sprintf("User %s has not linked an Annict account", origin.UserID))
+		b.replyEphemeralText(ctx, req, b.presenter.FormatAccountNotLinked())
+	case errors.As(err, &unknownErr):
+		slog.Info(fmt.Sprintf("Receive unknown command: %s", commandText))
+		b.replyText(ctx, req, b.presenter.FormatUnknownCommand(unknownErr))
+	case errors.As(err, &usageErr):
+		slog.Info(fmt.Sprintf("Invalid command usage: %v", usageErr))
+		b.replyText(ctx, req, b.presenter.FormatUsageError(usageErr))
+	default:
+		slog.Info(fmt.Sprintf("Command %q failed: %v", commandText, err))
+		b.replyText(ctx, req, b.presenter.FormatError(err))
+	}
+}
+
+// handleTodayCommand posts the calling user's programs for today and unwatched library entries.
+func (b *Bot) handleTodayCommand(ctx context.Context, req *annictcmd.Request) error {
+	slog.Info(fmt.Sprintf("Received command: '%s' from user %s", req.Command.Name, req.UserID))
+	services, err := b.servicesFor(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	input := b.todayInput(ctx, req.UserID, req.Flag(annictcmd.FLAG_REBROADCAST))
+	fallbackText, blocks, err := b.todayPrograms(ctx, services, input, req.UserID)
+	if err != nil {
+		return err
+	}
+	b.reply(ctx, req, fallbackText, blocks)
+	return nil
+}
+
+// todayInput applies the user's streaming subscriptions and channel preferences to today's programs.
+func (b *Bot) todayInput(ctx context.Context, slackUserID string, includeRebroadcasts bool) *usecase.AnnictInfoGetterInput {
+	subscriptions, err := b.subscriptions.Subscriptions(ctx, slackUserID)
+	if err != nil {
+		// Showing everything beats failing the command over a setting.
+		slog.Warn(fmt.Sprintf("Failed to read streaming subscriptions of %s: %v", slackUserID, err))
+	}
+	return &usecase.AnnictInfoGetterInput{
+		Subscriptions:       subscriptions,
+		Channels:            b.channelPreferencesFor(ctx, slackUserID),
+		IncludeRebroadcasts: includeRebroadcasts,
+	}
+}
+
+// PostDailyDigest posts today's programs and unwatched library entries without a mention.
+// It is intended to be run by the scheduler and uses the bot's default Annict account.
+// Later runs on the same broadcast day update the message instead of posting again.
+func (b *Bot) PostDailyDigest(ctx context.Context, channelID string) error {
+	fallbackText, blocks, err := b.todayPrograms(ctx, b.defaultServices, nil, "")
+	if err != nil {
+		return err
+	}
+
+	now := jst.Now()
+	timestamp, err := b.digests.Find(ctx, channelID, now)
+	if err != nil {
+		// Posting without knowing about today's digest could post it twice; let the scheduler retry.
+		return fmt.Errorf("failed to look up today's digest in %s: %w", channelID, err)
+	}
+	if timestamp != "" {
+		expanded, err := b.digests.Expanded(ctx, channelID, timestamp, now)
+		if err != nil {
+			// Updating could bring back the "もっと見る" button of an expanded digest.
+			return fmt.Errorf("failed to look up whether today's digest in %s was expanded: %w", channelID, err)
+		}
+		updated := blocks
+		if expanded {
+			updated = b.presenter.MarkProgramsExpanded(blocks)
+		}
+		if err := b.updateBlockMessage(ctx, channelID, timestamp, fallbackText, updated); err == nil {
+			slog.Info(fmt.Sprintf("Updated today's digest %s in %s", timestamp, channelID))
+			return nil
+		}
+		// The message may have been deleted; post a new one instead.
+	}
+
+	timestamp = b.postBlockMessage(ctx, channelID, fallbackText, blocks)
+	if timestamp == "" {
+		return fmt.Errorf("failed to post today's digest to channel %s", channelID)
+	}
+	if err := b.digests.Record(ctx, channelID, timestamp, now); err != nil {
+		slog.Warn(fmt.Sprintf("Failed to remember today's digest in %s: %v", channelID, err))
+	}
+	return nil
+}
+
+// todayPrograms fetches today's programs and unwatched library entries and formats them.
+// owner is the Slack user whose account and settings the input came from, or "" for the
+// default account, so that the "もっと見る" button can fetch the same list again.
+func (b *Bot) todayPrograms(ctx context.Context, services *UserServices, input *usecase.AnnictInfoGetterInput, owner string) (string, []slack.Block, error) {
+	// Execute both use cases
+	var todayPrograms []*entity.Program
+	var libraryEntries []*entity.Program
+	var combinedErr error
+
+	// Fetch Annict
+	annictInfo, err := services.AnnictInfoGetter.Execute(ctx, input)
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error fetching today's programs: %v", err))
+		combinedErr = fmt.Errorf("annictからの情報取得エラー: %w", err)
+	} else if annictInfo != nil {
+		todayPrograms = annictInfo.Programs
+		libraryEntries = annictInfo.LibraryEntries
+	}
+
+	// Present the results
+	if combinedErr != nil && len(libraryEntries) == 0 && len(todayPrograms) == 0 {
+		return "", nil, combinedErr
+	}
+
+	page := annictcmd.ProgramsPage{
+		Owner:        owner,
+		Date:         jst.FormatDate(jst.Today()),
+		Rebroadcasts: input != nil && input.IncludeRebroadcasts,
+	}
+	blocks := b.presenter.FormatCombinedPrograms(todayPrograms, libraryEntries, jst.Today(), page)
+	fallbackText := fmt.Sprintf("%s のアニメ情報 + 未視聴", jst.FormatDate(jst.Today()))
+
+	if combinedErr != nil {
+		// Optionally log or notify about partial errors
+		slog.Info(fmt.Sprintf("Partial error occurred during fetch: %v", combinedErr))
+	}
+	return fallbackText, blocks, nil
+}
+
+// handleShowMorePrograms posts the programs a today message left out. They are fetched
+// again for the list's owner, in the thread of the message, or below it for ephemeral
+// replies, and the button is removed so the rest is only posted once.
+func (b *Bot) handleShowMorePrograms(ctx context.Context, callback slack.InteractionCallback, action *slack.BlockAction) {
+	page, err := annictcmd.DecodeProgramsPageValue(action.Value)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("Ignored malformed programs page action: %v", err))
+		return
+	}
+	slog.Info(fmt.Sprintf("Received more programs request from user %s for %+v", callback.User.ID, page))
+	if page.Date != jst.FormatDate(jst.Today()) {
+		b.postInteractionEphemeral(ctx, callback, b.presenter.FormatProgramsPageExpired())
+		return
+	}
+
+	services := b.defaultServices
+	var input *usecase.AnnictInfoGetterInput
+	if page.Owner != "" {
+		services, err = b.servicesFor(ctx, page.Owner)
+		if err != nil {
+			b.postInteractionError(ctx, callback, err)
+			return
+		}
+		input = b.todayInput(ctx, page.Owner, page.Rebroadcasts)
+	}
+	info, err := services.AnnictInfoGetter.Execute(ctx, input)
+	if err != nil {
+		b.postInteractionError(ctx, callback, fmt.Errorf("annictからの情報取得エラー: %w", err))
+		return
+	}
+
+	if len(callback.Message.Blocks.BlockSet) > 0 {
+		blocks := b.presenter.MarkProgramsExpanded(callback.Message.Blocks.BlockSet)
+		b.updateInteractionMessage(ctx, callback, callback.Message.Text, blocks)
+	}
+	if !callback.Container.IsEphemeral {
+		// Keeps the hourly digest updates from bringing the button back.
+		if err := b.digests.MarkExpanded(ctx, callback.Channel.ID, callback.Message.Timestamp, jst.Now()); err != nil {
+			slog.Warn(fmt.Sprintf("Failed to remember the expanded digest in %s: %v", callback.Channel.ID, err))
+		}
+	}
+	var programs []*entity.Program
+	if info != nil {
+		programs = info.Programs
+	}
+	fallbackText := fmt.Sprintf("%s の放送予定の続き", page.Date)
+	for _, blocks := range b.presenter.FormatMorePrograms(programs, jst.Today(), page) {
+		if callback.Container.IsEphemeral && callback.ResponseURL != "" {
+			b.respond(ctx, callback.ResponseURL, &slack.WebhookMessage{
+				ResponseType: slack.ResponseTypeEphemeral,
+				Text:         fallbackText,
+				Blocks:       &slack.Blocks{BlockSet: blocks},
+			})
+			continue
+		}
+		threadTS := threadOf(callback)
+		if threadTS == "" {
+			threadTS = callback.Message.Timestamp
+		}
+		b.postThreadBlockMessage(ctx, callback.Channel.ID, threadTS, fallbackText, blocks)
+	}
+}
+
+// handleWeekCommand posts a weekly summary and the programs of each day in its thread.
+func (b *Bot) handleWeekCommand(ctx context.Context, req *annictcmd.Request) error {
+	slog.Info(fmt.Sprintf("Received command: '%s' from user %s", req.Command.Name, req.UserID))
+	services, err := b.servicesFor(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	input := &usecase.WeeklyScheduleInput{
+		Channels:            b.channelPreferencesFor(ctx, req.UserID),
+		IncludeRebroadcasts: req.Flag(annictcmd.FLAG_REBROADCAST),
+	}
+	schedule, err := services.WeeklySchedule.Execute(ctx, input)
+	if err != nil {
+		return fmt.Errorf("annictからの情報取得エラー: %w", err)
+	}
+
+	fallbackText := fmt.Sprintf("%s からの週間放送予定", jst.FormatDate(jst.Today()))
+	if req.ResponseURL != "" {
+		// Replies to slash commands have no thread, so the days follow the summary.
+		b.reply(ctx, req, fallbackText, b.presenter.FormatWeeklySchedule(schedule.Days))
+		return nil
+	}
+	// Mentions in a thread keep the whole schedule there; otherwise the summary starts a new thread.
+	threadTS := req.ThreadTS
+	summaryTS := b.postThreadBlockMessage(ctx, req.ChannelID, threadTS, fallbackText, b.presenter.FormatWeeklySummary(schedule.Days))
+	if summaryTS == "" {
+		return nil
+	}
+	if threadTS == "" {
+		threadTS = summaryTS
+	}
+	for _, day := range schedule.Days {
+		if len(day.Programs) == 0 {
+			continue
+		}
+		dayFallback := fmt.Sprintf("%s の放送予定", jst.FormatDate(day.Date))
+		b.postThreadBlockMessage(ctx, req.ChannelID, threadTS, dayFallback, b.presenter.FormatDaySchedule(day))
+	}
+	return nil
+}
+
+// handleStatusCommand changes a work's watch status from "annict status <title> <state>".
+func (b *Bot) handleStatusCommand(ctx context.Context, req *annictcmd.Request) error {
+	status, err := entity.ParseWatchStatus(req.Arg("state"))
+	if err != nil {
+		return &annictcmd.UsageError{Command: req.Command, Reason: err.Error()}
+	}
+	title := req.Arg("title")
+
+	services, err := b.servicesFor(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	work, err := services.StatusUpdater.ExecuteByTitle(ctx, title, status)
+	if err != nil {
+		return fmt.Errorf("ステータス変更エラー: %w", err)
+	}
+	b.replyText(ctx, req, b.presenter.FormatStatusUpdated(work))
+	b.refreshHome(ctx, req.UserID)
+	return nil
+}
+
+// handleSearchCommand searches Annict's works from "annict search <keyword...> [--popular]".
+// Words such as "2026-autumn" or "2026秋" narrow the search to those seasons.
+func (b *Bot) handleSearchCommand(ctx context.Context, req *annictcmd.Request) error {
+	slog.Info(fmt.Sprintf("Received command: '%s' from user %s", req.Command.Name, req.UserID))
+	query := &usecase.WorkSearchQuery{OrderByWatchers: req.Flag(annictcmd.FLAG_POPULAR)}
+	var titleWords []string
+	for _, word := range strings.Fields(req.Arg("keyword")) {
+		if season, ok := jst.ParseAnnictSeason(word); ok {
+			query.Seasons = append(query.Seasons, season)
+			continue
+		}
+		titleWords = append(titleWords, word)
+	}
+	query.Title = strings.Join(titleWords, " ")
+
+	services, err := b.catalogServicesFor(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	page, err := services.WorkSearcher.Execute(ctx, query)
+	if errors.Is(err, usecase.ErrEmptySearchQuery) {
+		return &annictcmd.UsageError{Command: req.Command, Reason: "検索するタイトルかシーズンを指定してください"}
+	}
+	if err != nil {
+		return fmt.Errorf("作品の検索エラー: %w", err)
+	}
+	fallbackText := fmt.Sprintf("「%s」の検索結果", req.Arg("keyword"))
+	b.reply(ctx, req, fallbackText, b.presenter.FormatSearchResults(query, page.Works))
+	return nil
+}
+
+// handleSeasonCommand posts the first page of the lineup from "annict season [season...]".
+func (b *Bot) handleSeasonCommand(ctx context.Context, req *annictcmd.Request) error {
+	slog.Info(fmt.Sprintf("Received command: '%s' from user %s", req.Command.Name, req.UserID))
+	var seasons []string
+	for _, word := range strings.Fields(req.Arg("seasons")) {
+		season, ok := jst.ParseAnnictSeason(word)
+		if !ok {
+			return &annictcmd.UsageError{Command: req.Command, Reason: fmt.Sprintf("シーズンの形式が正しくありません: %s (例: 2026-autumn)", word)}
+		}
+		seasons = append(seasons, season)
+	}
+
+	services, err := b.catalogServicesFor(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	lineup, err := services.SeasonLineup.Execute(ctx, &usecase.SeasonLineupInput{Seasons: seasons})
+	if err != nil {
+		return fmt.Errorf("シーズン作品の取得エラー: %w", err)
+	}
+	fallbackText := fmt.Sprintf("%s の作品一覧", strings.Join(lineup.Seasons, ", "))
+	b.reply(ctx, req, fallbackText, b.presenter.FormatSeasonLineup(lineup, nil, req.UserID))
+	return nil
+}
+
+// handlePreviewCommand posts the calling user's WANNA_WATCH works of the next season.
+func (b *Bot) handlePreviewCommand(ctx context.Context, req *annictcmd.Request) error {
+	slog.Info(fmt.Sprintf("Received command: '%s' from user %s", req.Command.Name, req.UserID))
+	services, err := b.servicesFor(ctx, req.UserID)
+	if err != nil {
+		return err
+	}
+	fallbackText, blocks, err := b.seasonPreview(ctx, services)
+	if err != nil {
+		return err
+	}
+	b.reply(ctx, req, fallbackText, blocks)
+	return nil
+}
+
+// PostSeasonPreview posts the "新クール開始" digest once the next season is due, which
+// is a fixed number of days before it starts; otherwise it does nothing. It is
+// intended to be run daily by the scheduler and uses the bot's default Annict account.
+func (b *Bot) PostSeasonPreview(ctx context.Context, channelID string) error {
+	now := jst.Now()
+	due, err := b.defaultServices.SeasonPreview.Due(ctx, now)
+	if err != nil {
+		return err
+	}
+	if !due {
+		slog.Debug("Skipped season preview: the next season is not due or already previewed")
+		return nil
+	}
+	fallbackText, blocks, err := b.seasonPreview(ctx, b.defaultServices)
+	if err != nil {
+		return err
+	}
+	messageTS := b.postBlockMessage(ctx, channelID, fallbackText, blocks)
+	if messageTS == "" {
+		return fmt.Errorf("failed to post season preview to channel %s", channelID)
+	}
+	if err := b.defaultServices.SeasonPreview.MarkPosted(ctx, channelID, messageTS, now); err != nil {
+		// Not returned: a retry would post the digest again.
+		slog.Warn(fmt.Sprintf("Failed to record season preview: %v", err))
+	}
+	return nil
+}
+
+// seasonPreview formats the next season's WANNA_WATCH works of the services' account.
+func (b *Bot) seasonPreview(ctx context.Context, services *UserServices) (string, []slack.Block, error) {
+	preview, err := services.SeasonPreview.Execute(ctx)
+	if err != nil {
+		return "", nil, fmt.Errorf("来期の作品の取得エラー: %w", err)
+	}
+	fallbackText := fmt.Sprintf("新クール開始: %s", preview.Season)
+	return fallbackText, b.presenter.FormatSeasonPreview(preview), nil
+}
+
+// handleServicesCommand shows or replaces the calling user's streaming subscriptions
+// from "annict services [name, ...]" ("clear" removes them).
+func (b *Bot) handleServicesCommand(ctx context.Context, req *annictcmd.Request) error {
+	known, err := b.subscriptions.KnownServices(ctx)
+	if errors.Is(err, usecase.ErrStreamingNotConfigured) {
+		b.replyText(ctx, req, b.presenter.FormatStreamingNotConfigured())
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("配信サービスの取得エラー: %w", err)
+	}
+
+	arg := strings.TrimSpace(req.Arg("services"))
+	var subscribed []string
+	switch {
+	case arg == "":
+		subscribed, err = b.subscriptions.Subscriptions(ctx, req.UserID)
+	case strings.EqualFold(arg, "clear") || strings.EqualFold(arg, "none"):
+		err = b.subscriptions.Unsubscribe(ctx, req.UserID)
+	default:
+		names := strings.FieldsFunc(arg, func(r rune) bool { return r == ',' || r == '、' })
+		for i := range names {
+			names[i] = strings.TrimSpace(names[i])
+		}
+		subscribed, err = b.subscriptions.Subscribe(ctx, req.UserID, names)
+		var unknownErr *usecase.UnknownStreamingServiceError
+		if errors.As(err, &unknownErr) {
+			return &annictcmd.UsageError{Command: req.Command, Reason: fmt.Sprintf("不明な配信サービスです: %s", unknownErr.Name)}
+		}
+	}
+	if err != nil {
+		return fmt.Errorf("配信サービスの設定エラー: %w", err)
+	}
+	b.replyText(ctx, req, b.presenter.FormatStreamingSubscriptions(subscribed, known))
+	return nil
+}
+
+// handleHelpCommand lists all commands, or shows the usage of a single one.
+func (b *Bot) handleHelpCommand(ctx context.Context, req *annictcmd.Request) error {
+	commands := b.commands.Commands()
+	if name := req.Arg("command"); name != "" {
+		cmd, ok := b.commands.Lookup(name)
+		if !ok {
+			return &annictcmd.UnknownCommandError{Input: name}
+		}
+		commands = []*annictcmd.Command{cmd}
+	}
+	b.replyText(ctx, req, b.presenter.FormatHelp(commands))
+	return nil
+}
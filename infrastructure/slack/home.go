@@ -0,0 +1,57 @@
+package slack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+	"github.com/monchh/annict-slack-bot/usecase"
+
+	"github.com/slack-go/slack"
+)
+
+// publishHome renders the user's App Home tab as their dashboard with views.publish.
+func (b *Bot) publishHome(ctx context.Context, slackUserID string) {
+	view, err := b.homeView(ctx, slackUserID)
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error building Home tab of user %s: %v", slackUserID, err))
+		return
+	}
+	if _, err := b.slackClient.PublishViewContext(ctx, slackUserID, view, ""); err != nil {
+		slog.Info(fmt.Sprintf("Error publishing Home tab of user %s: %v", slackUserID, err))
+		return
+	}
+	slog.Info(fmt.Sprintf("Published Home tab of user %s", slackUserID))
+}
+
+// refreshHome re-renders the user's Home tab in the background, e.g. after a record
+// or status change, so the reply to the change is not held up by Annict.
+func (b *Bot) refreshHome(ctx context.Context, slackUserID string) {
+	go b.publishHome(ctx, slackUserID)
+}
+
+// homeView builds the Home tab from the user's Annict account. Parts that cannot be
+// fetched are shown as unavailable rather than failing the whole view.
+func (b *Bot) homeView(ctx context.Context, slackUserID string) (slack.HomeTabViewRequest, error) {
+	services, err := b.servicesFor(ctx, slackUserID)
+	if errors.Is(err, usecase.ErrAccountNotLinked) {
+		return b.presenter.FormatHomeNotLinked(), nil
+	}
+	if err != nil {
+		return slack.HomeTabViewRequest{}, err
+	}
+
+	info, err := services.AnnictInfoGetter.Execute(ctx, b.todayInput(ctx, slackUserID, false))
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error fetching today's programs for the Home tab of user %s: %v", slackUserID, err))
+		info = nil
+	}
+	stats, err := services.WatchStats.Execute(ctx)
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error fetching watch statistics for the Home tab of user %s: %v", slackUserID, err))
+		stats = nil
+	}
+	return b.presenter.FormatHome(info, stats, jst.Today()), nil
+}
@@ -0,0 +1,33 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/slack-go/slack"
+)
+
+// ChannelNotifier posts broadcast reminders to a fixed channel.
+type ChannelNotifier struct {
+	bot       *Bot
+	channelID string
+}
+
+// NewChannelNotifier creates a notifier that posts to the channel through the bot.
+func (b *Bot) NewChannelNotifier(channelID string) *ChannelNotifier {
+	return &ChannelNotifier{
+		bot:       b,
+		channelID: channelID,
+	}
+}
+
+// NotifyUpcoming posts a reminder that the program starts soon.
+func (n *ChannelNotifier) NotifyUpcoming(ctx context.Context, program *entity.Program) error {
+	text := n.bot.presenter.FormatReminder(program)
+	_, _, err := n.bot.slackClient.PostMessageContext(ctx, n.channelID, slack.MsgOptionText(text, false))
+	if err != nil {
+		return fmt.Errorf("failed to post reminder to channel %s: %w", n.channelID, err)
+	}
+	return nil
+}
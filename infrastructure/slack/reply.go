@@ -0,0 +1,126 @@
+package slack
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/monchh/annict-slack-bot/usecase/annictcmd"
+
+	"github.com/slack-go/slack"
+)
+
+// reply posts the reply to a command and returns its timestamp, or "" when unknown.
+// Mentions are answered in the channel. Slash commands are answered through their
+// response_url, only to the caller for personal commands.
+func (b *Bot) reply(ctx context.Context, req *annictcmd.Request, fallbackText string, blocks []slack.Block) string {
+	if req.ResponseURL == "" {
+		return b.postThreadBlockMessage(ctx, req.ChannelID, req.ThreadTS, fallbackText, blocks)
+	}
+	b.respond(ctx, req.ResponseURL, &slack.WebhookMessage{
+		ResponseType: slashResponseType(req),
+		Text:         fallbackText,
+		Blocks:       &slack.Blocks{BlockSet: blocks},
+	})
+	return ""
+}
+
+// replyText is reply for plain text.
+func (b *Bot) replyText(ctx context.Context, req *annictcmd.Request, text string) {
+	if req.ResponseURL == "" {
+		b.postTextMessage(ctx, req.ChannelID, req.ThreadTS, text)
+		return
+	}
+	b.respond(ctx, req.ResponseURL, &slack.WebhookMessage{
+		ResponseType: slashResponseType(req),
+		Text:         text,
+	})
+}
+
+// replyEphemeral posts a reply only the caller can see, however the command was sent.
+func (b *Bot) replyEphemeral(ctx context.Context, req *annictcmd.Request, fallbackText string, blocks []slack.Block) {
+	if req.ResponseURL == "" {
+		b.postEphemeralBlockMessage(ctx, req.ChannelID, req.UserID, req.ThreadTS, fallbackText, blocks)
+		return
+	}
+	b.respond(ctx, req.ResponseURL, &slack.WebhookMessage{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         fallbackText,
+		Blocks:       &slack.Blocks{BlockSet: blocks},
+	})
+}
+
+// replyEphemeralText is replyEphemeral for plain text.
+func (b *Bot) replyEphemeralText(ctx context.Context, req *annictcmd.Request, text string) {
+	if req.ResponseURL == "" {
+		b.postEphemeralMessage(ctx, req.ChannelID, req.UserID, req.ThreadTS, text)
+		return
+	}
+	b.respond(ctx, req.ResponseURL, &slack.WebhookMessage{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         text,
+	})
+}
+
+// slashResponseType returns the visibility of a slash command reply. Replies to input
+// that did not resolve to a command, such as usage errors, are shown to the caller only.
+func slashResponseType(req *annictcmd.Request) string {
+	if req.Command == nil || req.Command.Personal {
+		return slack.ResponseTypeEphemeral
+	}
+	return slack.ResponseTypeInChannel
+}
+
+// respond posts a message to a response_url of a slash command or an interaction.
+func (b *Bot) respond(ctx context.Context, responseURL string, msg *slack.WebhookMessage) error {
+	err := slack.PostWebhookContext(ctx, responseURL, msg)
+	if err != nil {
+		slog.Info(fmt.Sprintf("Error posting to response_url: %v", err))
+	}
+	return err
+}
+
+// updateInteractionMessage replaces the message whose button was clicked through the
+// interaction's response_url. Unlike chat.update, it also works for ephemeral messages
+// and for slash command replies in channels the bot has not joined. Once the response_url
+// has expired (after 30 minutes), messages the bot can see are updated with chat.update.
+func (b *Bot) updateInteractionMessage(ctx context.Context, callback slack.InteractionCallback, fallbackText string, blocks []slack.Block) {
+	if callback.ResponseURL != "" {
+		err := b.respond(ctx, callback.ResponseURL, &slack.WebhookMessage{
+			ReplaceOriginal: true,
+			Text:            fallbackText,
+			Blocks:          &slack.Blocks{BlockSet: blocks},
+		})
+		if err == nil || callback.Container.IsEphemeral {
+			return
+		}
+	}
+	b.updateBlockMessage(ctx, callback.Channel.ID, callback.Message.Timestamp, fallbackText, blocks)
+}
+
+// threadOf returns the thread of the clicked message, empty for top-level messages.
+func threadOf(callback slack.InteractionCallback) string {
+	if callback.Message.ThreadTimestamp != "" {
+		return callback.Message.ThreadTimestamp
+	}
+	return callback.Container.ThreadTs
+}
+
+// postInteractionEphemeral posts text only the acting user can see. Clicks on ephemeral
+// messages are answered through their response_url, which also works in channels the
+// bot has not joined.
+func (b *Bot) postInteractionEphemeral(ctx context.Context, callback slack.InteractionCallback, text string) {
+	if callback.Channel.ID == "" {
+		// Clicks in the Home tab have no channel to answer in; the tab is re-rendered instead.
+		slog.Info(fmt.Sprintf("Dropped reply to user %s outside a channel: %q", callback.User.ID, text))
+		return
+	}
+	if !callback.Container.IsEphemeral || callback.ResponseURL == "" {
+		b.postEphemeralMessage(ctx, callback.Channel.ID, callback.User.ID, threadOf(callback), text)
+		return
+	}
+	b.respond(ctx, callback.ResponseURL, &slack.WebhookMessage{
+		ResponseType: slack.ResponseTypeEphemeral,
+		Text:         text,
+	})
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// Token returns the Annict token stored for the Slack user. The value is stored as
+// given, so it is encrypted only when the caller encrypts it (see credential.EncryptedTokenStore).
+func (s *Store) Token(ctx context.Context, slackUserID string) (string, bool, error) {
+	var token string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT token FROM annict_tokens WHERE slack_user_id = ?`,
+		slackUserID,
+	).Scan(&token)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read annict token of %s: %w", slackUserID, err)
+	}
+	return token, true, nil
+}
+
+// SaveToken stores the Annict token for the Slack user, replacing any previous one.
+func (s *Store) SaveToken(ctx context.Context, slackUserID, token string) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO annict_tokens (slack_user_id, token, updated_at) VALUES (?, ?, ?)
+		ON CONFLICT (slack_user_id) DO UPDATE SET token = excluded.token, updated_at = excluded.updated_at`,
+		slackUserID, token, time.Now().Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save annict token of %s: %w", slackUserID, err)
+	}
+	return nil
+}
+
+// DeleteToken removes the Slack user's Annict token. Deleting a missing token is not an error.
+func (s *Store) DeleteToken(ctx context.Context, slackUserID string) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM annict_tokens WHERE slack_user_id = ?`,
+		slackUserID,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete annict token of %s: %w", slackUserID, err)
+	}
+	return nil
+}
@@ -0,0 +1,84 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/usecase"
+)
+
+// GetCache returns the cached entry for the key, including expired ones.
+func (s *Store) GetCache(ctx context.Context, key string) (*usecase.CacheEntry, bool, error) {
+	entry := &usecase.CacheEntry{}
+	var storedAt, expiresAt int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT value, stored_at, expires_at FROM annict_cache WHERE key = ?`,
+		key,
+	).Scan(&entry.Value, &storedAt, &expiresAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read cache %s: %w", key, err)
+	}
+	entry.StoredAt = time.Unix(storedAt, 0)
+	entry.ExpiresAt = time.Unix(expiresAt, 0)
+	return entry, true, nil
+}
+
+// SetCache creates or replaces the cached entry for the key.
+func (s *Store) SetCache(ctx context.Context, key string, entry *usecase.CacheEntry) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO annict_cache (key, value, stored_at, expires_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (key) DO UPDATE SET
+			value = excluded.value,
+			stored_at = excluded.stored_at,
+			expires_at = excluded.expires_at`,
+		key, entry.Value, entry.StoredAt.Unix(), entry.ExpiresAt.Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to write cache %s: %w", key, err)
+	}
+	return nil
+}
+
+// DeleteCache removes the cached entry for the key.
+func (s *Store) DeleteCache(ctx context.Context, key string) error {
+	if _, err := s.db.ExecContext(ctx, `DELETE FROM annict_cache WHERE key = ?`, key); err != nil {
+		return fmt.Errorf("failed to delete cache %s: %w", key, err)
+	}
+	return nil
+}
+
+// DeleteCacheByPrefix removes every cached entry whose key starts with the prefix.
+func (s *Store) DeleteCacheByPrefix(ctx context.Context, prefix string) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM annict_cache WHERE key LIKE ? ESCAPE '\'`,
+		escapeLike(prefix)+"%",
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete cache with prefix %s: %w", prefix, err)
+	}
+	return nil
+}
+
+// PruneCache deletes entries under the key prefix that expired before the given time.
+func (s *Store) PruneCache(ctx context.Context, prefix string, before time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM annict_cache WHERE key LIKE ? ESCAPE '\' AND expires_at < ?`,
+		escapeLike(prefix)+"%", before.Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to prune cache: %w", err)
+	}
+	return nil
+}
+
+// escapeLike escapes the LIKE wildcards so the prefix is matched literally.
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(s)
+}
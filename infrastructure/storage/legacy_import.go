@@ -0,0 +1,126 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// ImportReminderHistory copies the sent reminders of the JSON file used before the database
+// (REMINDER_HISTORY_FILE, a map of reminder key to sent time) into the notification history,
+// so programs reminded just before an upgrade are not reminded again. Entries already in the
+// database are kept. The file is renamed afterwards so the import runs only once.
+// A missing file is not an error; it returns the number of imported entries.
+func (s *Store) ImportReminderHistory(ctx context.Context, path string) (int, error) {
+	var sent map[string]time.Time
+	return s.importLegacyFile(ctx, path, "reminder history", &sent, func(tx *sql.Tx) (int, error) {
+		imported := 0
+		for key, sentAt := range sent {
+			result, err := tx.ExecContext(ctx,
+				`INSERT INTO notification_history (kind, key, channel_id, message_ts, sent_at) VALUES (?, ?, '', '', ?)
+				ON CONFLICT (kind, key) DO NOTHING`,
+				string(entity.NotificationKindReminder), key, sentAt.Unix(),
+			)
+			if err != nil {
+				return 0, fmt.Errorf("failed to import reminder %s: %w", key, err)
+			}
+			imported += rowsAffected(result)
+		}
+		return imported, nil
+	})
+}
+
+// ImportSchedulerState copies the last run times of the JSON file used before the database
+// (SCHEDULE_STATE_FILE, a map of job name to time), so a restart after the upgrade neither
+// repeats nor replays a run. Like ImportReminderHistory, it keeps entries already in the
+// database and renames the file.
+func (s *Store) ImportSchedulerState(ctx context.Context, path string) (int, error) {
+	var state map[string]time.Time
+	return s.importLegacyFile(ctx, path, "scheduler state", &state, func(tx *sql.Tx) (int, error) {
+		imported := 0
+		for name, lastRun := range state {
+			result, err := tx.ExecContext(ctx,
+				`INSERT INTO scheduler_state (job_name, last_run) VALUES (?, ?)
+				ON CONFLICT (job_name) DO NOTHING`,
+				name, lastRun.Unix(),
+			)
+			if err != nil {
+				return 0, fmt.Errorf("failed to import last run of job %s: %w", name, err)
+			}
+			imported += rowsAffected(result)
+		}
+		return imported, nil
+	})
+}
+
+// ImportTokens copies the linked Annict tokens of the JSON file used before the database
+// (ANNICT_TOKEN_STORE_FILE, a map of Slack user ID to token). Values are copied as they are,
+// encrypted or not; plaintext ones are encrypted on their first read as before. Like
+// ImportReminderHistory, it keeps entries already in the database and renames the file.
+func (s *Store) ImportTokens(ctx context.Context, path string) (int, error) {
+	var tokens map[string]string
+	return s.importLegacyFile(ctx, path, "annict tokens", &tokens, func(tx *sql.Tx) (int, error) {
+		imported := 0
+		now := time.Now().Unix()
+		for slackUserID, token := range tokens {
+			result, err := tx.ExecContext(ctx,
+				`INSERT INTO annict_tokens (slack_user_id, token, updated_at) VALUES (?, ?, ?)
+				ON CONFLICT (slack_user_id) DO NOTHING`,
+				slackUserID, token, now,
+			)
+			if err != nil {
+				return 0, fmt.Errorf("failed to import annict token of %s: %w", slackUserID, err)
+			}
+			imported += rowsAffected(result)
+		}
+		return imported, nil
+	})
+}
+
+// importLegacyFile parses the JSON file at path into v, runs insert in a transaction and
+// renames the file to path+".imported" so it is imported only once. A missing file is not
+// an error. It returns the number of entries insert reports as imported.
+func (s *Store) importLegacyFile(ctx context.Context, path, what string, v any, insert func(tx *sql.Tx) (int, error)) (int, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to read %s %s: %w", what, path, err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return 0, fmt.Errorf("failed to parse %s %s: %w", what, path, err)
+	}
+
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin %s import: %w", what, err)
+	}
+	defer tx.Rollback()
+	imported, err := insert(tx)
+	if err != nil {
+		return 0, err
+	}
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit %s import: %w", what, err)
+	}
+
+	if err := os.Rename(path, path+".imported"); err != nil {
+		return imported, fmt.Errorf("failed to rename imported %s %s: %w", what, path, err)
+	}
+	return imported, nil
+}
+
+// rowsAffected returns the number of rows an insert added, 0 when unknown.
+func rowsAffected(result sql.Result) int {
+	n, err := result.RowsAffected()
+	if err != nil {
+		return 0
+	}
+	return int(n)
+}
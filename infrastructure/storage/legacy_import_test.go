@@ -0,0 +1,116 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+func TestImportReminderHistory(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := Open(ctx, filepath.Join(dir, "bot.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer store.Close()
+
+	// Reminded in the database after the upgrade; the file must not overwrite it.
+	recent := time.Date(2026, time.October, 16, 21, 0, 0, 0, time.UTC)
+	if err := store.RecordNotification(ctx, &entity.Notification{Kind: entity.NotificationKindReminder, Key: "program:2", SentAt: recent}); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "reminder_history.json")
+	legacy := `{"program:1":"2026-10-16T20:50:00+09:00","program:2":"2026-10-16T20:00:00+09:00"}`
+	if err := os.WriteFile(path, []byte(legacy), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	imported, err := store.ImportReminderHistory(ctx, path)
+	if err != nil || imported != 1 {
+		t.Fatalf("ImportReminderHistory = %d, %v; want 1, nil", imported, err)
+	}
+	n, ok, err := store.FindNotification(ctx, entity.NotificationKindReminder, "program:1")
+	if err != nil || !ok {
+		t.Fatalf("FindNotification(program:1) = %v, %v; want the imported reminder", ok, err)
+	}
+	if want := time.Date(2026, time.October, 16, 20, 50, 0, 0, time.FixedZone("JST", 9*60*60)); !n.SentAt.Equal(want) {
+		t.Errorf("SentAt = %s, want %s", n.SentAt, want)
+	}
+	if n, _, _ := store.FindNotification(ctx, entity.NotificationKindReminder, "program:2"); n == nil || !n.SentAt.Equal(recent) {
+		t.Errorf("existing reminder was overwritten: %+v", n)
+	}
+
+	if _, err := os.Stat(path); !os.IsNotExist(err) {
+		t.Errorf("history file still exists after the import: %v", err)
+	}
+	if _, err := os.Stat(path + ".imported"); err != nil {
+		t.Errorf("imported history file is missing: %v", err)
+	}
+	if imported, err := store.ImportReminderHistory(ctx, path); err != nil || imported != 0 {
+		t.Errorf("second ImportReminderHistory = %d, %v; want 0, nil", imported, err)
+	}
+}
+
+func TestImportSchedulerStateAndTokens(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	store, err := Open(ctx, filepath.Join(dir, "bot.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer store.Close()
+
+	// Written after the upgrade; the files must not overwrite them.
+	recent := time.Date(2026, time.October, 16, 7, 0, 0, 0, time.UTC)
+	if err := store.SetLastRun(ctx, "weekly", recent); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SaveToken(ctx, "U2", "relinked-token"); err != nil {
+		t.Fatal(err)
+	}
+
+	statePath := filepath.Join(dir, "scheduler_state.json")
+	state := `{"daily":"2026-10-15T07:00:00+09:00","weekly":"2026-10-12T07:00:00+09:00"}`
+	if err := os.WriteFile(statePath, []byte(state), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tokensPath := filepath.Join(dir, "annict_tokens.json")
+	if err := os.WriteFile(tokensPath, []byte(`{"U1":"token-1","U2":"old-token"}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if imported, err := store.ImportSchedulerState(ctx, statePath); err != nil || imported != 1 {
+		t.Fatalf("ImportSchedulerState = %d, %v; want 1, nil", imported, err)
+	}
+	want := time.Date(2026, time.October, 15, 7, 0, 0, 0, time.FixedZone("JST", 9*60*60))
+	if got, ok, err := store.LastRun(ctx, "daily"); err != nil || !ok || !got.Equal(want) {
+		t.Errorf("LastRun(daily) = %s, %v, %v; want %s", got, ok, err, want)
+	}
+	if got, _, _ := store.LastRun(ctx, "weekly"); !got.Equal(recent) {
+		t.Errorf("existing last run was overwritten: %s", got)
+	}
+
+	if imported, err := store.ImportTokens(ctx, tokensPath); err != nil || imported != 1 {
+		t.Fatalf("ImportTokens = %d, %v; want 1, nil", imported, err)
+	}
+	if token, ok, err := store.Token(ctx, "U1"); err != nil || !ok || token != "token-1" {
+		t.Errorf("Token(U1) = %q, %v, %v; want token-1", token, ok, err)
+	}
+	if token, _, _ := store.Token(ctx, "U2"); token != "relinked-token" {
+		t.Errorf("existing token was overwritten: %q", token)
+	}
+
+	for _, path := range []string{statePath, tokensPath} {
+		if _, err := os.Stat(path); !os.IsNotExist(err) {
+			t.Errorf("%s still exists after the import: %v", path, err)
+		}
+		if _, err := os.Stat(path + ".imported"); err != nil {
+			t.Errorf("imported file is missing: %v", err)
+		}
+	}
+}
@@ -0,0 +1,25 @@
+CREATE TABLE user_settings (
+    slack_user_id TEXT    NOT NULL,
+    key           TEXT    NOT NULL,
+    value         TEXT    NOT NULL,
+    updated_at    INTEGER NOT NULL,
+    PRIMARY KEY (slack_user_id, key)
+);
+
+CREATE TABLE notification_history (
+    kind       TEXT    NOT NULL,
+    key        TEXT    NOT NULL,
+    channel_id TEXT    NOT NULL DEFAULT '',
+    message_ts TEXT    NOT NULL DEFAULT '',
+    sent_at    INTEGER NOT NULL,
+    PRIMARY KEY (kind, key)
+);
+
+CREATE INDEX idx_notification_history_sent_at ON notification_history (sent_at);
+
+CREATE TABLE annict_cache (
+    key        TEXT    NOT NULL PRIMARY KEY,
+    value      BLOB    NOT NULL,
+    stored_at  INTEGER NOT NULL,
+    expires_at INTEGER NOT NULL
+);
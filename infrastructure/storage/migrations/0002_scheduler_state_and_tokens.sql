@@ -0,0 +1,10 @@
+CREATE TABLE scheduler_state (
+    job_name TEXT    NOT NULL PRIMARY KEY,
+    last_run INTEGER NOT NULL
+);
+
+CREATE TABLE annict_tokens (
+    slack_user_id TEXT    NOT NULL PRIMARY KEY,
+    token         TEXT    NOT NULL,
+    updated_at    INTEGER NOT NULL
+);
@@ -0,0 +1,54 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// RecordNotification stores the notification, replacing an entry with the same kind and key.
+func (s *Store) RecordNotification(ctx context.Context, n *entity.Notification) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO notification_history (kind, key, channel_id, message_ts, sent_at) VALUES (?, ?, ?, ?, ?)
+		ON CONFLICT (kind, key) DO UPDATE SET
+			channel_id = excluded.channel_id,
+			message_ts = excluded.message_ts,
+			sent_at = excluded.sent_at`,
+		string(n.Kind), n.Key, n.ChannelID, n.MessageTS, n.SentAt.Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to record %s notification %s: %w", n.Kind, n.Key, err)
+	}
+	return nil
+}
+
+// FindNotification returns the notification of the kind and key.
+func (s *Store) FindNotification(ctx context.Context, kind entity.NotificationKind, key string) (*entity.Notification, bool, error) {
+	n := &entity.Notification{Kind: kind, Key: key}
+	var sentAt int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT channel_id, message_ts, sent_at FROM notification_history WHERE kind = ? AND key = ?`,
+		string(kind), key,
+	).Scan(&n.ChannelID, &n.MessageTS, &sentAt)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read %s notification %s: %w", kind, key, err)
+	}
+	n.SentAt = time.Unix(sentAt, 0)
+	return n, true, nil
+}
+
+// PruneNotifications deletes entries of the kind sent before the given time.
+func (s *Store) PruneNotifications(ctx context.Context, kind entity.NotificationKind, before time.Time) error {
+	_, err := s.db.ExecContext(ctx, `DELETE FROM notification_history WHERE kind = ? AND sent_at < ?`, string(kind), before.Unix())
+	if err != nil {
+		return fmt.Errorf("failed to prune %s notification history: %w", kind, err)
+	}
+	return nil
+}
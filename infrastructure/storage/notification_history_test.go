@@ -0,0 +1,39 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+func TestPruneNotificationsKeepsOtherKinds(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer store.Close()
+
+	old := time.Date(2026, time.October, 1, 9, 0, 0, 0, time.UTC)
+	for _, n := range []*entity.Notification{
+		{Kind: entity.NotificationKindReminder, Key: "program:1", SentAt: old},
+		{Kind: entity.NotificationKindSeasonPreview, Key: "2027-winter", SentAt: old},
+	} {
+		if err := store.RecordNotification(ctx, n); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if err := store.PruneNotifications(ctx, entity.NotificationKindReminder, old.AddDate(0, 0, 7)); err != nil {
+		t.Fatalf("PruneNotifications returned error: %v", err)
+	}
+	if _, ok, _ := store.FindNotification(ctx, entity.NotificationKindReminder, "program:1"); ok {
+		t.Error("old reminder was not pruned")
+	}
+	if _, ok, _ := store.FindNotification(ctx, entity.NotificationKindSeasonPreview, "2027-winter"); !ok {
+		t.Error("season preview was pruned with the reminders")
+	}
+}
@@ -0,0 +1,40 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+)
+
+// LastRun returns the last scheduled run time of the job.
+func (s *Store) LastRun(ctx context.Context, name string) (time.Time, bool, error) {
+	var lastRun int64
+	err := s.db.QueryRowContext(ctx,
+		`SELECT last_run FROM scheduler_state WHERE job_name = ?`,
+		name,
+	).Scan(&lastRun)
+	if errors.Is(err, sql.ErrNoRows) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to read last run of job %s: %w", name, err)
+	}
+	return time.Unix(lastRun, 0).In(jst.Location()), true, nil
+}
+
+// SetLastRun records the last scheduled run time of the job.
+func (s *Store) SetLastRun(ctx context.Context, name string, t time.Time) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO scheduler_state (job_name, last_run) VALUES (?, ?)
+		ON CONFLICT (job_name) DO UPDATE SET last_run = excluded.last_run`,
+		name, t.Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save last run of job %s: %w", name, err)
+	}
+	return nil
+}
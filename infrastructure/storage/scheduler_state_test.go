@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestLastRunRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer store.Close()
+
+	if _, ok, err := store.LastRun(ctx, "daily"); ok || err != nil {
+		t.Fatalf("LastRun of a new job = %v, %v; want false, nil", ok, err)
+	}
+	first := time.Date(2026, time.October, 15, 7, 0, 0, 0, time.UTC)
+	second := first.AddDate(0, 0, 1)
+	for _, run := range []time.Time{first, second} {
+		if err := store.SetLastRun(ctx, "daily", run); err != nil {
+			t.Fatalf("SetLastRun returned error: %v", err)
+		}
+	}
+	got, ok, err := store.LastRun(ctx, "daily")
+	if err != nil || !ok || !got.Equal(second) {
+		t.Errorf("LastRun = %s, %v, %v; want %s", got, ok, err, second)
+	}
+}
+
+func TestTokenRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	store, err := Open(ctx, filepath.Join(t.TempDir(), "bot.db"))
+	if err != nil {
+		t.Fatalf("Open returned error: %v", err)
+	}
+	defer store.Close()
+
+	for _, token := range []string{"token-1", "token-2"} {
+		if err := store.SaveToken(ctx, "U1", token); err != nil {
+			t.Fatalf("SaveToken returned error: %v", err)
+		}
+	}
+	if token, ok, err := store.Token(ctx, "U1"); err != nil || !ok || token != "token-2" {
+		t.Errorf("Token = %q, %v, %v; want token-2, true, nil", token, ok, err)
+	}
+
+	if err := store.DeleteToken(ctx, "U1"); err != nil {
+		t.Fatalf("DeleteToken returned error: %v", err)
+	}
+	if _, ok, err := store.Token(ctx, "U1"); ok || err != nil {
+		t.Errorf("Token after DeleteToken = %v, %v; want false, nil", ok, err)
+	}
+	if err := store.DeleteToken(ctx, "U1"); err != nil {
+		t.Errorf("DeleteToken of a missing token returned error: %v", err)
+	}
+}
@@ -0,0 +1,46 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+
+	_ "modernc.org/sqlite" // Pure-Go SQLite driver registered as "sqlite"
+)
+
+// Store is the bot's durable state, kept in an embedded SQLite database.
+// It implements the storage repositories defined in the usecase package.
+type Store struct {
+	db *sql.DB
+}
+
+// Open opens (or creates) the database file and applies pending migrations.
+func Open(ctx context.Context, path string) (*Store, error) {
+	params := url.Values{}
+	params.Add("_pragma", "busy_timeout(5000)")
+	params.Add("_pragma", "journal_mode(WAL)")
+	params.Add("_pragma", "foreign_keys(1)")
+	db, err := sql.Open("sqlite", "file:"+path+"?"+params.Encode())
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database %s: %w", path, err)
+	}
+	// SQLite allows a single writer; one connection avoids "database is locked" between goroutines.
+	db.SetMaxOpenConns(1)
+
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database %s: %w", path, err)
+	}
+	store := &Store{db: db}
+	if err := store.migrate(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return store, nil
+}
+
+// Close closes the database.
+func (s *Store) Close() error {
+	return s.db.Close()
+}
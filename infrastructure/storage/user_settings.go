@@ -0,0 +1,50 @@
+package storage
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// GetUserSetting returns the value of the user's setting.
+func (s *Store) GetUserSetting(ctx context.Context, slackUserID, key string) (string, bool, error) {
+	var value string
+	err := s.db.QueryRowContext(ctx,
+		`SELECT value FROM user_settings WHERE slack_user_id = ? AND key = ?`,
+		slackUserID, key,
+	).Scan(&value)
+	if errors.Is(err, sql.ErrNoRows) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to read setting %s of %s: %w", key, slackUserID, err)
+	}
+	return value, true, nil
+}
+
+// SetUserSetting creates or replaces the user's setting.
+func (s *Store) SetUserSetting(ctx context.Context, slackUserID, key, value string) error {
+	_, err := s.db.ExecContext(ctx,
+		`INSERT INTO user_settings (slack_user_id, key, value, updated_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (slack_user_id, key) DO UPDATE SET value = excluded.value, updated_at = excluded.updated_at`,
+		slackUserID, key, value, time.Now().Unix(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save setting %s of %s: %w", key, slackUserID, err)
+	}
+	return nil
+}
+
+// DeleteUserSetting removes the user's setting. Deleting a missing setting is not an error.
+func (s *Store) DeleteUserSetting(ctx context.Context, slackUserID, key string) error {
+	_, err := s.db.ExecContext(ctx,
+		`DELETE FROM user_settings WHERE slack_user_id = ? AND key = ?`,
+		slackUserID, key,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to delete setting %s of %s: %w", key, slackUserID, err)
+	}
+	return nil
+}
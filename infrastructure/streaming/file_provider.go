@@ -0,0 +1,99 @@
+package streaming
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// mappingFile is the layout of the mapping file. JSON files use the same keys,
+// since YAML is a superset of JSON:
+//
+//	works:
+//	  - annict_id: 12345
+//	    syobocal_tid: 6789
+//	    services:
+//	      - name: dアニメストア
+//	        url: https://animestore.docomo.ne.jp/animestore/ci_pc?workId=12345
+type mappingFile struct {
+	Works []struct {
+		AnnictID    int64 `yaml:"annict_id"`
+		SyobocalTID int64 `yaml:"syobocal_tid"`
+		Services    []struct {
+			Name string `yaml:"name"`
+			URL  string `yaml:"url"`
+		} `yaml:"services"`
+	} `yaml:"works"`
+}
+
+// FileProvider implements usecase.StreamingProvider with a local YAML or JSON file
+// mapping Annict work IDs and Syobocal TIDs to streaming service URLs.
+type FileProvider struct {
+	byAnnictID    map[int64][]entity.StreamingService
+	bySyobocalTID map[int64][]entity.StreamingService
+	known         []string
+}
+
+// NewFileProvider loads the mapping file.
+func NewFileProvider(path string) (*FileProvider, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read streaming mapping %s: %w", path, err)
+	}
+	var mapping mappingFile
+	if err := yaml.Unmarshal(data, &mapping); err != nil {
+		return nil, fmt.Errorf("failed to parse streaming mapping %s: %w", path, err)
+	}
+
+	p := &FileProvider{
+		byAnnictID:    make(map[int64][]entity.StreamingService),
+		bySyobocalTID: make(map[int64][]entity.StreamingService),
+	}
+	seen := make(map[string]bool)
+	for i, work := range mapping.Works {
+		if work.AnnictID == 0 && work.SyobocalTID == 0 {
+			return nil, fmt.Errorf("streaming mapping %s: works[%d] needs annict_id or syobocal_tid", path, i)
+		}
+		var services []entity.StreamingService
+		for j, s := range work.Services {
+			if s.Name == "" || s.URL == "" {
+				return nil, fmt.Errorf("streaming mapping %s: works[%d].services[%d] needs name and url", path, i, j)
+			}
+			services = append(services, entity.StreamingService{Name: s.Name, URL: s.URL})
+			if !seen[s.Name] {
+				seen[s.Name] = true
+				p.known = append(p.known, s.Name)
+			}
+		}
+		if work.AnnictID != 0 {
+			p.byAnnictID[work.AnnictID] = append(p.byAnnictID[work.AnnictID], services...)
+		}
+		if work.SyobocalTID != 0 {
+			p.bySyobocalTID[work.SyobocalTID] = append(p.bySyobocalTID[work.SyobocalTID], services...)
+		}
+	}
+	sort.Strings(p.known)
+	return p, nil
+}
+
+// StreamingServices returns the services of the work, matched by Annict work ID first
+// and Syobocal TID second.
+func (p *FileProvider) StreamingServices(ctx context.Context, work *entity.Work) ([]entity.StreamingService, error) {
+	if services, ok := p.byAnnictID[work.AnnictID]; ok && work.AnnictID != 0 {
+		return services, nil
+	}
+	if services, ok := p.bySyobocalTID[work.SyobocalTID]; ok && work.SyobocalTID != 0 {
+		return services, nil
+	}
+	return nil, nil
+}
+
+// KnownServices returns the names of every service in the mapping, sorted.
+func (p *FileProvider) KnownServices(ctx context.Context) ([]string, error) {
+	return p.known, nil
+}
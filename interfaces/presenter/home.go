@@ -0,0 +1,120 @@
+package presenter
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+	"github.com/monchh/annict-slack-bot/usecase"
+	"github.com/monchh/annict-slack-bot/usecase/annictcmd"
+	"github.com/slack-go/slack"
+)
+
+// maxHomeBlocks is the number of blocks Slack accepts in a Home tab view.
+const maxHomeBlocks = 100
+
+// FormatHome formats the App Home tab as a personal dashboard: watch statistics, today's
+// programs and the next library entries, from the same output as FormatCombinedPrograms.
+// Today's programs that do not fit within the block limit are counted instead of shown.
+// info or stats may be nil when they could not be fetched.
+func (p *SlackProgramPresenter) FormatHome(info *usecase.AnnictInfoGetterOutput, stats *entity.WatchStats, date time.Time) slack.HomeTabViewRequest {
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, ":house: マイダッシュボード", true, false)),
+		slack.NewContextBlock("",
+			slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("最終更新: %s", jst.FormatDateTime(jst.Now())), false, false),
+		),
+	}
+	blocks = append(blocks, formatWatchStats(stats)...)
+	blocks = append(blocks, slack.NewDividerBlock())
+
+	var todaysPrograms, libraryEntries []*entity.Program
+	if info != nil {
+		todaysPrograms = info.Programs
+		libraryEntries = info.LibraryEntries
+	}
+	if len(libraryEntries) > p.annictLimitNumToDisplay {
+		libraryEntries = libraryEntries[:p.annictLimitNumToDisplay]
+	}
+
+	// The library entries are laid out first so that today's programs get the rest of the budget.
+	libraryBlocks := []slack.Block{
+		slack.NewDividerBlock(),
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, ":eyes: 次に見るアニメ", true, false)),
+	}
+	switch {
+	case info == nil:
+		libraryBlocks = append(libraryBlocks, formatHomeNotice("ライブラリを取得できませんでした。"))
+	case len(libraryEntries) == 0:
+		libraryBlocks = append(libraryBlocks, formatHomeNotice("未視聴のアニメは見つかりませんでした。"))
+	default:
+		libraryBlocks = append(libraryBlocks, p.formatProgramList(libraryEntries)...)
+	}
+
+	headerText := fmt.Sprintf(":calendar: %s 放送予定のアニメ", jst.FormatDate(date))
+	blocks = append(blocks, slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, headerText, true, false)))
+	switch {
+	case info == nil:
+		blocks = append(blocks, formatHomeNotice("放送予定を取得できませんでした。"))
+	case len(todaysPrograms) == 0:
+		blocks = append(blocks, formatHomeNotice("本日の放送予定は見つかりませんでした。"))
+	default:
+		// One block is kept for the "more" notice.
+		programBlocks, shown := p.fitProgramList(todaysPrograms, maxHomeBlocks-len(blocks)-len(libraryBlocks)-1)
+		blocks = append(blocks, programBlocks...)
+		if rest := len(todaysPrograms) - shown; rest > 0 {
+			blocks = append(blocks, slack.NewContextBlock("",
+				slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("他 %d 件は `%s %s` で表示できます。", rest, annictcmd.COMMAND_PREFIX, annictcmd.CMD_TODAY), false, false),
+			))
+		}
+	}
+	blocks = append(blocks, libraryBlocks...)
+
+	return slack.HomeTabViewRequest{
+		Type:   slack.VTHomeTab,
+		Blocks: slack.Blocks{BlockSet: blocks},
+	}
+}
+
+// FormatHomeNotLinked formats the App Home tab of a user without a linked Annict account.
+func (p *SlackProgramPresenter) FormatHomeNotLinked() slack.HomeTabViewRequest {
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, ":house: マイダッシュボード", true, false)),
+	}
+	blocks = append(blocks, p.FormatLinkPrompt()...)
+	return slack.HomeTabViewRequest{
+		Type:   slack.VTHomeTab,
+		Blocks: slack.Blocks{BlockSet: blocks},
+	}
+}
+
+// formatWatchStats formats the counts of the viewer's library as section fields.
+func formatWatchStats(stats *entity.WatchStats) []slack.Block {
+	if stats == nil {
+		return []slack.Block{formatHomeNotice("視聴統計を取得できませんでした。")}
+	}
+	counts := []struct {
+		label string
+		count int
+	}{
+		{watchStatusLabel(entity.WatchStatusWatching), stats.Watching},
+		{watchStatusLabel(entity.WatchStatusWannaWatch), stats.WannaWatch},
+		{watchStatusLabel(entity.WatchStatusWatched), stats.Watched},
+		{watchStatusLabel(entity.WatchStatusOnHold), stats.OnHold},
+		{watchStatusLabel(entity.WatchStatusStopWatching), stats.StopWatching},
+		{"記録", stats.Records},
+	}
+	fields := make([]*slack.TextBlockObject, 0, len(counts))
+	for _, c := range counts {
+		fields = append(fields, slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("*%s*\n%s", c.label, formatCount(c.count)), false, false))
+	}
+	return []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, ":bar_chart: *視聴統計*", false, false), nil, nil),
+		slack.NewSectionBlock(nil, fields, nil),
+	}
+}
+
+// formatHomeNotice formats a single line of text in the Home tab.
+func formatHomeNotice(text string) slack.Block {
+	return slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil)
+}
@@ -4,12 +4,24 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/monchh/annict-slack-bot/domain/entity"
 	"github.com/monchh/annict-slack-bot/pkg/jst"
+	"github.com/monchh/annict-slack-bot/usecase"
+	"github.com/monchh/annict-slack-bot/usecase/annictcmd"
 	"github.com/slack-go/slack"
 )
 
+// maxSectionTextLength keeps section text below Slack's 3000 character limit.
+const maxSectionTextLength = 2900
+
+// maxMessageBlocks is the number of blocks Slack accepts in one message.
+const maxMessageBlocks = 50
+
+// programsExpandedNote is appended to the "他 N 件" note once the rest was posted.
+const programsExpandedNote = " 続きを表示しました。"
+
 // SlackProgramPresenter formats domain entities into Slack Block Kit blocks.
 type SlackProgramPresenter struct {
 	annictLimitNumToDisplay int
@@ -22,11 +34,14 @@ func NewSlackProgramPresenter(limit int) *SlackProgramPresenter {
 	}
 }
 
-// FormatCombinedPrograms formats both today's and unwatched programs.
+// FormatCombinedPrograms formats both today's and unwatched programs in one message.
+// Today's programs that do not fit within Slack's limits are counted instead, with
+// a "もっと見る" button carrying page, whose cursor is set to the first program left out.
 func (p *SlackProgramPresenter) FormatCombinedPrograms(
 	todaysPrograms []*entity.Program,
 	unwatchedPrograms []*entity.Program,
 	date time.Time,
+	page annictcmd.ProgramsPage,
 ) []slack.Block {
 	todayStr := jst.FormatDate(date)
 	var blocks []slack.Block
@@ -36,41 +51,161 @@ func (p *SlackProgramPresenter) FormatCombinedPrograms(
 	headerBlock := slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, headerText, true, false))
 	blocks = append(blocks, headerBlock)
 
-	if len(todaysPrograms) == 0 {
-		noResultsBlock := slack.NewSectionBlock(
-			slack.NewTextBlockObject(slack.MarkdownType, "本日の放送予定は見つかりませんでした。", false, false),
-			nil, nil,
-		)
-		blocks = append(blocks, noResultsBlock)
-	} else {
-		blocks = append(blocks, p.formatProgramList(todaysPrograms)...)
+	// The unwatched programs are laid out first so that today's programs get the rest of the blocks.
+	unwatchedBlocks := []slack.Block{
+		slack.NewDividerBlock(),
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, ":eyes: 未視聴のアニメ", true, false)),
 	}
-
-	// Divider
-	blocks = append(blocks, slack.NewDividerBlock())
-
-	// Header for Unwatched Programs
-	unwatchedHeaderText := ":eyes: 未視聴のアニメ"
-	unwatchedHeaderBlock := slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, unwatchedHeaderText, true, false))
-	blocks = append(blocks, unwatchedHeaderBlock)
-
 	if len(unwatchedPrograms) == 0 {
 		noUnwatchedBlock := slack.NewSectionBlock(
 			slack.NewTextBlockObject(slack.MarkdownType, "未視聴のアニメは見つかりませんでした。", false, false),
 			nil, nil,
 		)
-		blocks = append(blocks, noUnwatchedBlock)
+		unwatchedBlocks = append(unwatchedBlocks, noUnwatchedBlock)
 	} else {
 		// Limit the number of unwatched recommendations shown.
 		limit := p.annictLimitNumToDisplay
 		if len(unwatchedPrograms) > limit {
 			unwatchedPrograms = unwatchedPrograms[:limit]
-			// Add a note that more exist?
 		}
-		blocks = append(blocks, p.formatProgramList(unwatchedPrograms)...)
+		// Room is kept for the header and the "more" notice of today's programs.
+		programBlocks, _ := p.fitProgramList(unwatchedPrograms, maxMessageBlocks-len(unwatchedBlocks)-2)
+		unwatchedBlocks = append(unwatchedBlocks, programBlocks...)
 	}
 
-	return blocks
+	if len(todaysPrograms) == 0 {
+		noResultsBlock := slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, "本日の放送予定は見つかりませんでした。", false, false),
+			nil, nil,
+		)
+		blocks = append(blocks, noResultsBlock)
+	} else {
+		// One block is kept for the "more" notice.
+		programBlocks, shown := p.fitProgramList(todaysPrograms, maxMessageBlocks-len(blocks)-len(unwatchedBlocks)-1)
+		blocks = append(blocks, programBlocks...)
+		if rest := len(todaysPrograms) - shown; rest > 0 {
+			page.FirstID = todaysPrograms[shown].ID
+			page.FirstStart = todaysPrograms[shown].StartTime
+			blocks = append(blocks, formatMorePrograms(rest, page))
+		}
+	}
+
+	return append(blocks, unwatchedBlocks...)
+}
+
+// FormatMorePrograms formats today's programs from the first one page points to on, i.e.
+// the ones a FormatCombinedPrograms message left out, split into as many messages as needed.
+func (p *SlackProgramPresenter) FormatMorePrograms(todaysPrograms []*entity.Program, date time.Time, page annictcmd.ProgramsPage) [][]slack.Block {
+	from := programsFrom(todaysPrograms, page)
+	if from >= len(todaysPrograms) {
+		return [][]slack.Block{{
+			slack.NewSectionBlock(
+				slack.NewTextBlockObject(slack.MarkdownType, "残りの放送予定は見つかりませんでした。", false, false),
+				nil, nil,
+			),
+		}}
+	}
+
+	var messages [][]slack.Block
+	for from < len(todaysPrograms) {
+		// One block is kept for the context.
+		programBlocks, shown := p.fitProgramList(todaysPrograms[from:], maxMessageBlocks-1)
+		if shown == 0 {
+			break // A single program never exceeds the limits, but never loop forever
+		}
+		blocks := []slack.Block{formatMoreProgramsContext(date, from, from+shown, len(todaysPrograms))}
+		messages = append(messages, append(blocks, programBlocks...))
+		from += shown
+	}
+	return messages
+}
+
+// MarkProgramsExpanded returns the blocks of an already posted message with its
+// "もっと見る" button removed, once the rest of the programs was posted.
+// The blocks passed in are left unchanged.
+func (p *SlackProgramPresenter) MarkProgramsExpanded(blocks []slack.Block) []slack.Block {
+	marked := make([]slack.Block, len(blocks))
+	for i, block := range blocks {
+		marked[i] = block
+		section, ok := block.(*slack.SectionBlock)
+		if !ok || section.Accessory == nil || section.Accessory.ButtonElement == nil ||
+			section.Accessory.ButtonElement.ActionID != annictcmd.ACTION_SHOW_MORE_PROGRAMS {
+			continue
+		}
+		expanded := *section
+		expanded.Accessory = nil
+		if section.Text != nil {
+			text := *section.Text
+			text.Text += programsExpandedNote
+			expanded.Text = &text
+		}
+		marked[i] = &expanded
+	}
+	return marked
+}
+
+// FormatProgramsPageExpired tells the user that a "もっと見る" button belongs to a past broadcast day.
+func (p *SlackProgramPresenter) FormatProgramsPageExpired() string {
+	return fmt.Sprintf(":hourglass: この一覧は前の放送日のものです。`%s %s` で今日の放送予定を表示できます。", annictcmd.COMMAND_PREFIX, annictcmd.CMD_TODAY)
+}
+
+// formatMorePrograms formats the "他 N 件" note with the button posting the rest of the programs.
+func formatMorePrograms(rest int, page annictcmd.ProgramsPage) slack.Block {
+	button := slack.NewButtonBlockElement(
+		annictcmd.ACTION_SHOW_MORE_PROGRAMS,
+		annictcmd.EncodeProgramsPageValue(page),
+		slack.NewTextBlockObject(slack.PlainTextType, "もっと見る", true, false),
+	)
+	return slack.NewSectionBlock(
+		slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("他 %d 件の放送予定があります。", rest), false, false),
+		nil, slack.NewAccessory(button),
+	)
+}
+
+// formatMoreProgramsContext formats the context heading a message of FormatMorePrograms
+// that shows the programs from index from up to (but not including) to.
+func formatMoreProgramsContext(date time.Time, from, to, total int) slack.Block {
+	text := fmt.Sprintf(":calendar: %s 放送予定の続き (%d〜%d 件目 / 全 %d 件)", jst.FormatDate(date), from+1, to, total)
+	return slack.NewContextBlock("", slack.NewTextBlockObject(slack.MarkdownType, text, false, false))
+}
+
+// programsFrom returns the index of the first program page points to, or len(programs)
+// when none is left. Programs are listed latest first, so once that program is no longer
+// listed (e.g. it was watched meanwhile), the rest starts at the first one not starting after it.
+func programsFrom(programs []*entity.Program, page annictcmd.ProgramsPage) int {
+	for i, program := range programs {
+		if program.ID == page.FirstID {
+			return i
+		}
+	}
+	for i, program := range programs {
+		if !program.StartTime.After(page.FirstStart) {
+			return i
+		}
+	}
+	return len(programs)
+}
+
+// truncateText cuts text to at most maxLen characters, marking the cut with an ellipsis.
+func truncateText(text string, maxLen int) string {
+	if utf8.RuneCountInString(text) <= maxLen {
+		return text
+	}
+	return string([]rune(text)[:maxLen-1]) + "…"
+}
+
+// fitProgramList formats the leading programs whose blocks fit within maxBlocks
+// and returns the blocks with the number of programs formatted.
+func (p *SlackProgramPresenter) fitProgramList(programs []*entity.Program, maxBlocks int) ([]slack.Block, int) {
+	var blocks []slack.Block
+	for i, program := range programs {
+		programBlocks := p.formatProgramList([]*entity.Program{program})
+		if len(blocks)+len(programBlocks) > maxBlocks {
+			return blocks, i
+		}
+		blocks = append(blocks, programBlocks...)
+	}
+	return blocks, len(programs)
 }
 
 // formatProgramList formats a list of programs into blocks (used by FormatCombinedPrograms).
@@ -89,21 +224,24 @@ func (p *SlackProgramPresenter) formatProgramList(programs []*entity.Program) []
 		} else {
 			title = fmt.Sprintf("*%s*", program.Work.Title)
 		}
-		textBuilder.WriteString(fmt.Sprintf("%s\n", title))
+		textBuilder.WriteString(fmt.Sprintf("%s%s\n", title, formatProgramLabels(program)))
 
 		episodeTitleStr := ""
 		if program.Episode.Title != nil && *program.Episode.Title != "" {
 			episodeTitleStr = fmt.Sprintf("「%s」", *program.Episode.Title)
 		}
 		// For unwatched, show air date as well?
-		airDateTimeStr := fmt.Sprintf("%s %s", jst.FormatDate(program.StartTime), jst.FormatTime(program.StartTime))
+		airDateTimeStr := jst.FormatDateTime(program.StartTime)
 		textBuilder.WriteString(fmt.Sprintf(" • %s %s\n",
 			program.Episode.NumberText,
 			episodeTitleStr,
 		))
 		textBuilder.WriteString(fmt.Sprintf(" • :tv: %s %s", program.Channel.Name, airDateTimeStr)) // Channel might be less relevant but okay
+		if streaming := formatStreamingLinks(program.Work.Streaming); streaming != "" {
+			textBuilder.WriteString(fmt.Sprintf("\n • 配信: %s", streaming))
+		}
 
-		sectionText := slack.NewTextBlockObject(slack.MarkdownType, textBuilder.String(), false, false)
+		sectionText := slack.NewTextBlockObject(slack.MarkdownType, truncateText(textBuilder.String(), maxSectionTextLength), false, false)
 		sectionBlock := slack.NewSectionBlock(sectionText, nil, nil)
 
 		// Optional Image Block
@@ -120,11 +258,523 @@ func (p *SlackProgramPresenter) formatProgramList(programs []*entity.Program) []
 		if imageBlock != nil {
 			blocks = append(blocks, imageBlock)
 		}
+		if actionBlock := p.formatProgramActions(program); actionBlock != nil {
+			blocks = append(blocks, actionBlock)
+		}
+	}
+	return blocks
+}
+
+// formatProgramLabels returns the markers of rebroadcasts and cancelled programs, prefixed with a space.
+func formatProgramLabels(program *entity.Program) string {
+	var labels string
+	if program.IsHidden() {
+		labels += " :no_entry_sign: _非公開・放送中止_"
+	}
+	if program.Rebroadcast {
+		labels += " :repeat: _再放送_"
+	}
+	return labels
+}
+
+// formatStreamingLinks formats the services as "<url|dアニメストア> / <url|ABEMA>".
+func formatStreamingLinks(services []entity.StreamingService) string {
+	links := make([]string, 0, len(services))
+	for _, s := range services {
+		links = append(links, fmt.Sprintf("<%s|%s>", s.URL, s.Name))
+	}
+	return strings.Join(links, " / ")
+}
+
+// formatProgramActions builds the record button and status menu for a program.
+// Returns nil when neither the episode nor the work can be identified.
+func (p *SlackProgramPresenter) formatProgramActions(program *entity.Program) *slack.ActionBlock {
+	var elements []slack.BlockElement
+	if program.Episode.ID != "" {
+		recordButton := slack.NewButtonBlockElement(
+			annictcmd.ACTION_RECORD_EPISODE,
+			program.Episode.ID,
+			slack.NewTextBlockObject(slack.PlainTextType, "視聴済みにする", true, false),
+		).WithStyle(slack.StylePrimary)
+		elements = append(elements, recordButton)
+	}
+	if program.Work.ID != "" {
+		var options []*slack.OptionBlockObject
+		for _, status := range entity.ChangeableWatchStatuses {
+			if status == program.Work.Status {
+				continue
+			}
+			options = append(options, slack.NewOptionBlockObject(
+				annictcmd.EncodeStatusActionValue(program.Work.ID, string(status)),
+				slack.NewTextBlockObject(slack.PlainTextType, fmt.Sprintf("「%s」にする", watchStatusLabel(status)), true, false),
+				nil,
+			))
+		}
+		elements = append(elements, slack.NewOverflowBlockElement(annictcmd.ACTION_UPDATE_STATUS, options...))
+	}
+	if len(elements) == 0 {
+		return nil
+	}
+	return slack.NewActionBlock("", elements...)
+}
+
+// FormatWeeklySummary formats the parent message of the weekly schedule.
+// The programs of each day are posted separately with FormatDaySchedule.
+func (p *SlackProgramPresenter) FormatWeeklySummary(days []*usecase.DaySchedule) []slack.Block {
+	var blocks []slack.Block
+	if len(days) == 0 {
+		return blocks
+	}
+
+	headerText := fmt.Sprintf(":calendar: 今週の放送予定 (%s〜%s)", formatShortDate(days[0].Date), formatShortDate(days[len(days)-1].Date))
+	blocks = append(blocks, slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, headerText, true, false)))
+
+	var textBuilder strings.Builder
+	total := 0
+	for _, day := range days {
+		total += len(day.Programs)
+		if len(day.Programs) == 0 {
+			textBuilder.WriteString(fmt.Sprintf("• %s: なし\n", formatShortDate(day.Date)))
+			continue
+		}
+		textBuilder.WriteString(fmt.Sprintf("• %s: %d 本\n", formatShortDate(day.Date), len(day.Programs)))
+	}
+	blocks = append(blocks, slack.NewSectionBlock(
+		slack.NewTextBlockObject(slack.MarkdownType, textBuilder.String(), false, false),
+		nil, nil,
+	))
+
+	if total > 0 {
+		blocks = append(blocks, slack.NewContextBlock("",
+			slack.NewTextBlockObject(slack.MarkdownType, ":thread: 日ごとの放送予定はスレッドに投稿します。", false, false),
+		))
+	}
+	return blocks
+}
+
+// FormatWeeklySchedule formats the weekly summary followed by each day's programs in a
+// single message, for replies that cannot start a thread. Days that no longer fit within
+// Slack's limits are left out.
+func (p *SlackProgramPresenter) FormatWeeklySchedule(days []*usecase.DaySchedule) []slack.Block {
+	blocks := p.FormatWeeklySummary(days)
+	for _, day := range days {
+		if len(day.Programs) == 0 {
+			continue
+		}
+		dayBlocks := append([]slack.Block{slack.NewDividerBlock()}, p.FormatDaySchedule(day)...)
+		if len(blocks)+len(dayBlocks) > maxMessageBlocks {
+			break
+		}
+		blocks = append(blocks, dayBlocks...)
+	}
+	return blocks
+}
+
+// FormatDaySchedule formats one day of the weekly schedule as compact, time-ordered lines.
+func (p *SlackProgramPresenter) FormatDaySchedule(day *usecase.DaySchedule) []slack.Block {
+	var lines []string
+	for _, program := range day.Programs {
+		line := fmt.Sprintf("`%s` *%s* %s", jst.FormatTime(program.StartTime), program.Work.Title, program.Episode.NumberText)
+		if program.IsHidden() {
+			// Strike through cancelled programs so the slot is still visible.
+			line = fmt.Sprintf("~%s~", line)
+		}
+		if program.Channel.Name != "" {
+			line += fmt.Sprintf(" (%s)", program.Channel.Name)
+		}
+		line += formatProgramLabels(program)
+		lines = append(lines, line)
+	}
+
+	blocks := []slack.Block{
+		slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf(":date: *%s*", formatShortDate(day.Date)), false, false),
+			nil, nil,
+		),
+	}
+	for _, chunk := range chunkLines(lines, maxSectionTextLength) {
+		blocks = append(blocks, slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, chunk, false, false),
+			nil, nil,
+		))
 	}
 	return blocks
 }
 
+// formatShortDate formats a date like "10/16 (金)".
+func formatShortDate(t time.Time) string {
+	weekdays := []string{"日", "月", "火", "水", "木", "金", "土"}
+	jstTime := t.In(jst.Location())
+	return fmt.Sprintf("%s (%s)", jst.Format(jstTime, "01/02"), weekdays[jstTime.Weekday()])
+}
+
+// chunkLines joins lines with newlines into chunks of at most maxLen characters,
+// counted the way Slack counts them rather than in bytes.
+func chunkLines(lines []string, maxLen int) []string {
+	var chunks []string
+	var current strings.Builder
+	currentLen := 0
+	for _, line := range lines {
+		lineLen := utf8.RuneCountInString(line)
+		if current.Len() > 0 && currentLen+lineLen+1 > maxLen {
+			chunks = append(chunks, current.String())
+			current.Reset()
+			currentLen = 0
+		}
+		if current.Len() > 0 {
+			current.WriteString("\n")
+			currentLen++
+		}
+		current.WriteString(line)
+		currentLen += lineLen
+	}
+	if current.Len() > 0 {
+		chunks = append(chunks, current.String())
+	}
+	return chunks
+}
+
+// MarkEpisodeRecorded rewrites the blocks of an already posted message so that
+// the given episode is shown as recorded and its record button is removed.
+func (p *SlackProgramPresenter) MarkEpisodeRecorded(blocks []slack.Block, episodeID string) []slack.Block {
+	var rewritten []slack.Block
+	var lastSection *slack.SectionBlock
+	for _, block := range blocks {
+		switch b := block.(type) {
+		case *slack.SectionBlock:
+			lastSection = b
+		case *slack.ActionBlock:
+			if b.Elements == nil || !removeRecordButton(b.Elements, episodeID) {
+				break
+			}
+			if lastSection != nil && lastSection.Text != nil {
+				lastSection.Text.Text += "\n • :white_check_mark: 視聴済み"
+			}
+			if len(b.Elements.ElementSet) == 0 {
+				continue // Drop the now empty actions block
+			}
+		}
+		rewritten = append(rewritten, block)
+	}
+	return rewritten
+}
+
+// removeRecordButton removes the record button for the episode and reports whether it was found.
+func removeRecordButton(elements *slack.BlockElements, episodeID string) bool {
+	for i, element := range elements.ElementSet {
+		button, ok := element.(*slack.ButtonBlockElement)
+		if !ok || button.ActionID != annictcmd.ACTION_RECORD_EPISODE || button.Value != episodeID {
+			continue
+		}
+		elements.ElementSet = append(elements.ElementSet[:i], elements.ElementSet[i+1:]...)
+		return true
+	}
+	return false
+}
+
+// FormatStatusUpdated formats the confirmation message for a status change.
+func (p *SlackProgramPresenter) FormatStatusUpdated(work *entity.Work) string {
+	return fmt.Sprintf(":white_check_mark: 「%s」のステータスを「%s」に変更しました。", work.Title, watchStatusLabel(work.Status))
+}
+
+// watchStatusLabel returns the label Annict uses for the status.
+func watchStatusLabel(status entity.WatchStatus) string {
+	switch status {
+	case entity.WatchStatusWatching:
+		return "見てる"
+	case entity.WatchStatusWannaWatch:
+		return "見たい"
+	case entity.WatchStatusOnHold:
+		return "一時中断"
+	case entity.WatchStatusStopWatching:
+		return "視聴中止"
+	case entity.WatchStatusWatched:
+		return "見た"
+	default:
+		return string(status)
+	}
+}
+
+// FormatReminder formats the reminder posted shortly before a program starts.
+func (p *SlackProgramPresenter) FormatReminder(program *entity.Program) string {
+	return fmt.Sprintf(":alarm_clock: まもなく放送: %s %s (%s) %s〜",
+		program.Work.Title,
+		program.Episode.NumberText,
+		program.Channel.Name,
+		jst.FormatTime(program.StartTime),
+	)
+}
+
+// FormatHelp formats the command list (with argument details when a single command is given).
+func (p *SlackProgramPresenter) FormatHelp(commands []*annictcmd.Command) string {
+	var textBuilder strings.Builder
+	textBuilder.WriteString(":book: *使えるコマンド*\n")
+	for _, cmd := range commands {
+		textBuilder.WriteString(fmt.Sprintf("• `%s` %s", cmd.Usage(), cmd.Help))
+		if len(cmd.Aliases) > 0 {
+			textBuilder.WriteString(fmt.Sprintf(" (別名: %s)", strings.Join(cmd.Aliases, ", ")))
+		}
+		textBuilder.WriteString("\n")
+		if len(commands) == 1 {
+			for _, arg := range cmd.Args {
+				textBuilder.WriteString(fmt.Sprintf("    ◦ `%s`: %s\n", arg.Name, arg.Help))
+			}
+			for _, flag := range cmd.Flags {
+				textBuilder.WriteString(fmt.Sprintf("    ◦ `--%s`: %s\n", flag.Name, flag.Help))
+			}
+		}
+	}
+	return textBuilder.String()
+}
+
+// FormatUnknownCommand formats the reply for unrecognized input, with a suggestion when available.
+func (p *SlackProgramPresenter) FormatUnknownCommand(err *annictcmd.UnknownCommandError) string {
+	msg := fmt.Sprintf(":thinking_face: 不明なコマンドです: `%s`", err.Input)
+	if err.Suggestion != "" {
+		msg += fmt.Sprintf("\nもしかして: `%s %s`", annictcmd.COMMAND_PREFIX, err.Suggestion)
+	}
+	return msg + fmt.Sprintf("\n`%s %s` でコマンド一覧を表示できます。", annictcmd.COMMAND_PREFIX, annictcmd.CMD_HELP)
+}
+
+// FormatUsageError formats the reply for a command invoked with invalid arguments.
+func (p *SlackProgramPresenter) FormatUsageError(err *annictcmd.UsageError) string {
+	return fmt.Sprintf(":warning: %s\n使い方: `%s`", err.Reason, err.Command.Usage())
+}
+
 // FormatError formats an error message for Slack.
 func (p *SlackProgramPresenter) FormatError(err error) string {
 	return fmt.Sprintf(":warning: エラーが発生しました:\n```%v```", err)
 }
+
+// FormatStreamingSubscriptions formats the user's subscribed services and the services to choose from.
+func (p *SlackProgramPresenter) FormatStreamingSubscriptions(subscribed, known []string) string {
+	var textBuilder strings.Builder
+	if len(subscribed) == 0 {
+		textBuilder.WriteString(":tv: 契約中の配信サービスは登録されていません。未視聴のアニメは絞り込まずに表示します。\n")
+	} else {
+		textBuilder.WriteString(fmt.Sprintf(":tv: 契約中の配信サービス: %s\n未視聴のアニメはこれらで配信中の作品に絞り込みます。\n", strings.Join(subscribed, " / ")))
+	}
+	textBuilder.WriteString(fmt.Sprintf("登録できるサービス: %s\n", strings.Join(known, " / ")))
+	textBuilder.WriteString(fmt.Sprintf("`%s %s dアニメストア, Netflix` で登録、`%s %s clear` で解除します。",
+		annictcmd.COMMAND_PREFIX, annictcmd.CMD_SERVICES, annictcmd.COMMAND_PREFIX, annictcmd.CMD_SERVICES))
+	return textBuilder.String()
+}
+
+// FormatStreamingNotConfigured formats the reply when no streaming data is configured.
+func (p *SlackProgramPresenter) FormatStreamingNotConfigured() string {
+	return ":tv: 配信サービスの情報が設定されていません。"
+}
+
+// FormatAuthorizeLink formats the DM that carries the Annict authorize URL.
+func (p *SlackProgramPresenter) FormatAuthorizeLink(authorizeURL string) []slack.Block {
+	text := ":link: 下のボタンから Annict にログインして、このボットにアクセスを許可してください。\nリンクは 10 分間、1 回だけ有効です。"
+	button := slack.NewButtonBlockElement(
+		annictcmd.ACTION_OPEN_AUTHORIZE_URL,
+		"",
+		slack.NewTextBlockObject(slack.PlainTextType, "Annict で許可する", true, false),
+	).WithStyle(slack.StylePrimary).WithURL(authorizeURL)
+	return []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil),
+		slack.NewActionBlock("", button),
+	}
+}
+
+// FormatAuthorizeLinkSent formats the ephemeral note that the authorize URL was sent by DM.
+func (p *SlackProgramPresenter) FormatAuthorizeLinkSent() string {
+	return ":envelope: 連携用のリンクを DM でお送りしました。"
+}
+
+// FormatLinkPrompt formats the ephemeral message that opens the account link modal.
+func (p *SlackProgramPresenter) FormatLinkPrompt() []slack.Block {
+	text := ":link: Annict の<https://annict.jp/settings/apps|個人用アクセストークン>を入力して、あなたのアカウントと連携してください。\nトークンはモーダルで入力するため、チャンネルには表示されません。"
+	button := slack.NewButtonBlockElement(
+		annictcmd.ACTION_OPEN_LINK,
+		annictcmd.ACTION_OPEN_LINK,
+		slack.NewTextBlockObject(slack.PlainTextType, "トークンを入力する", true, false),
+	).WithStyle(slack.StylePrimary)
+	return []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil),
+		slack.NewActionBlock("", button),
+	}
+}
+
+// FormatLinkModal formats the modal that asks for an Annict access token.
+// The channel ID is carried in the private metadata so the result can be reported there.
+func (p *SlackProgramPresenter) FormatLinkModal(channelID string) slack.ModalViewRequest {
+	input := slack.NewPlainTextInputBlockElement(
+		slack.NewTextBlockObject(slack.PlainTextType, "Annict の個人用アクセストークン", false, false),
+		annictcmd.ACTION_LINK_TOKEN_INPUT,
+	)
+	return slack.ModalViewRequest{
+		Type:            slack.VTModal,
+		CallbackID:      annictcmd.VIEW_LINK_ACCOUNT,
+		PrivateMetadata: channelID,
+		Title:           slack.NewTextBlockObject(slack.PlainTextType, "Annict と連携", false, false),
+		Submit:          slack.NewTextBlockObject(slack.PlainTextType, "連携する", false, false),
+		Close:           slack.NewTextBlockObject(slack.PlainTextType, "キャンセル", false, false),
+		Blocks: slack.Blocks{BlockSet: []slack.Block{
+			slack.NewInputBlock(
+				annictcmd.BLOCK_LINK_TOKEN,
+				slack.NewTextBlockObject(slack.PlainTextType, "アクセストークン", false, false),
+				slack.NewTextBlockObject(slack.PlainTextType, "読み込み / 書き込みの権限があるトークンを発行してください。", false, false),
+				input,
+			),
+		}},
+	}
+}
+
+// FormatAccountLinked formats the confirmation message after linking an account.
+func (p *SlackProgramPresenter) FormatAccountLinked(viewer *entity.Viewer) string {
+	return fmt.Sprintf(":white_check_mark: Annict アカウント @%s (%s) と連携しました。", viewer.Username, viewer.Name)
+}
+
+// FormatLinkFailed formats the reply when the token entered in the link modal could not be verified.
+func (p *SlackProgramPresenter) FormatLinkFailed() string {
+	return fmt.Sprintf(":warning: トークンを確認できませんでした。Annict で発行したトークンを `%s %s` からもう一度入力してください。", annictcmd.COMMAND_PREFIX, annictcmd.CMD_LINK)
+}
+
+// FormatAccountUnlinked formats the confirmation message after unlinking an account.
+func (p *SlackProgramPresenter) FormatAccountUnlinked() string {
+	return ":wave: Annict アカウントとの連携を解除しました。"
+}
+
+// FormatAccountNotLinked formats the reply for a user who has not linked an account yet.
+func (p *SlackProgramPresenter) FormatAccountNotLinked() string {
+	return fmt.Sprintf(":link: Annict アカウントが連携されていません。`%s %s` で連携してください。", annictcmd.COMMAND_PREFIX, annictcmd.CMD_LINK)
+}
+
+// FormatLinkingDisabled formats the reply to link commands while account linking is disabled.
+func (p *SlackProgramPresenter) FormatLinkingDisabled() string {
+	return ":lock: このワークスペースでは Annict アカウントの連携が無効になっています。すべてのコマンドは既定のアカウントで動作します。"
+}
+
+// FormatChannelPreferences formats the user's channel preferences with a button to edit them.
+func (p *SlackProgramPresenter) FormatChannelPreferences(prefs *entity.ChannelPreferences) []slack.Block {
+	text := formatChannelPreferencesText(prefs) + fmt.Sprintf(
+		"\n`%s %s allow NHK, TOKYO MX` や `%s %s prefer TOKYO MX, BS11` でも設定できます。",
+		annictcmd.COMMAND_PREFIX, annictcmd.CMD_CHANNELS, annictcmd.COMMAND_PREFIX, annictcmd.CMD_CHANNELS)
+	button := slack.NewButtonBlockElement(
+		annictcmd.ACTION_OPEN_CHANNEL_PREFERENCES,
+		annictcmd.ACTION_OPEN_CHANNEL_PREFERENCES,
+		slack.NewTextBlockObject(slack.PlainTextType, "チャンネルを設定する", true, false),
+	).WithStyle(slack.StylePrimary)
+	return []slack.Block{
+		slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, text, false, false), nil, nil),
+		slack.NewActionBlock("", button),
+	}
+}
+
+// FormatChannelPreferencesSaved formats the confirmation after the preferences were changed.
+func (p *SlackProgramPresenter) FormatChannelPreferencesSaved(prefs *entity.ChannelPreferences) string {
+	return ":white_check_mark: チャンネル設定を保存しました。\n" + formatChannelPreferencesText(prefs)
+}
+
+// formatChannelPreferencesText lists the allowed channels and the priority order.
+func formatChannelPreferencesText(prefs *entity.ChannelPreferences) string {
+	if prefs.IsEmpty() {
+		return ":satellite_antenna: チャンネルは設定されていません。すべてのチャンネルの放送を表示します。"
+	}
+	var textBuilder strings.Builder
+	if len(prefs.Allowed) == 0 {
+		textBuilder.WriteString(":satellite_antenna: 表示するチャンネル: すべて\n")
+	} else {
+		textBuilder.WriteString(fmt.Sprintf(":satellite_antenna: 表示するチャンネル: %s\n", channelNames(prefs.Allowed, " / ")))
+	}
+	if len(prefs.Priority) == 0 {
+		textBuilder.WriteString(":1234: 優先順: 未設定 (最も早い放送を表示)")
+	} else {
+		textBuilder.WriteString(fmt.Sprintf(":1234: 優先順: %s", channelNames(prefs.Priority, " > ")))
+	}
+	return textBuilder.String()
+}
+
+func channelNames(channels []entity.Channel, separator string) string {
+	names := make([]string, len(channels))
+	for i, ch := range channels {
+		names[i] = ch.Name
+	}
+	return strings.Join(names, separator)
+}
+
+// FormatChannelPreferencesModal formats the modal editing the channel preferences.
+// Both selects load the user's channels through block_suggestion payloads, and
+// the channel ID is carried in the private metadata so the result can be reported there.
+func (p *SlackProgramPresenter) FormatChannelPreferencesModal(prefs *entity.ChannelPreferences, channelID string) slack.ModalViewRequest {
+	if prefs == nil {
+		prefs = &entity.ChannelPreferences{}
+	}
+	allowed := channelSelectBlock(
+		annictcmd.BLOCK_CHANNEL_ALLOWED,
+		annictcmd.ACTION_CHANNEL_ALLOWED_SELECT,
+		"表示するチャンネル",
+		"選択したチャンネルの放送だけを表示します。空欄ならすべて表示します。",
+		prefs.Allowed,
+	)
+	priority := channelSelectBlock(
+		annictcmd.BLOCK_CHANNEL_PRIORITY,
+		annictcmd.ACTION_CHANNEL_PRIORITY_SELECT,
+		"優先するチャンネル (優先順に選択)",
+		"同じ話数が複数のチャンネルで放送される場合、先に選んだチャンネルの放送だけを表示します。",
+		prefs.Priority,
+	)
+	return slack.ModalViewRequest{
+		Type:            slack.VTModal,
+		CallbackID:      annictcmd.VIEW_CHANNEL_PREFERENCES,
+		PrivateMetadata: channelID,
+		Title:           slack.NewTextBlockObject(slack.PlainTextType, "チャンネル設定", false, false),
+		Submit:          slack.NewTextBlockObject(slack.PlainTextType, "保存する", false, false),
+		Close:           slack.NewTextBlockObject(slack.PlainTextType, "キャンセル", false, false),
+		Blocks:          slack.Blocks{BlockSet: []slack.Block{allowed, priority}},
+	}
+}
+
+func channelSelectBlock(blockID, actionID, label, hint string, selected []entity.Channel) *slack.InputBlock {
+	element := slack.NewOptionsMultiSelectBlockElement(
+		slack.MultiOptTypeExternal,
+		slack.NewTextBlockObject(slack.PlainTextType, "チャンネル名で検索", false, false),
+		actionID,
+	).WithMinQueryLength(0)
+	if len(selected) > 0 {
+		element = element.WithInitialOptions(channelOptions(selected)...)
+	}
+	block := slack.NewInputBlock(
+		blockID,
+		slack.NewTextBlockObject(slack.PlainTextType, label, false, false),
+		slack.NewTextBlockObject(slack.PlainTextType, hint, false, false),
+		element,
+	)
+	block.Optional = true
+	return block
+}
+
+// FormatChannelOptions formats the channels as select options grouped by channel group.
+func (p *SlackProgramPresenter) FormatChannelOptions(channels []entity.Channel) []*slack.OptionGroupBlockObject {
+	var groups []*slack.OptionGroupBlockObject
+	byName := make(map[string]*slack.OptionGroupBlockObject)
+	for _, ch := range channels {
+		label := ch.GroupName
+		if label == "" {
+			label = "その他"
+		}
+		group, ok := byName[label]
+		if !ok {
+			group = slack.NewOptionGroupBlockElement(slack.NewTextBlockObject(slack.PlainTextType, label, false, false))
+			byName[label] = group
+			groups = append(groups, group)
+		}
+		group.Options = append(group.Options, channelOptions([]entity.Channel{ch})...)
+	}
+	return groups
+}
+
+func channelOptions(channels []entity.Channel) []*slack.OptionBlockObject {
+	options := make([]*slack.OptionBlockObject, len(channels))
+	for i, ch := range channels {
+		options[i] = slack.NewOptionBlockObject(
+			annictcmd.EncodeChannelOptionValue(ch.AnnictID, ch.Name),
+			slack.NewTextBlockObject(slack.PlainTextType, ch.Name, false, false),
+			nil,
+		)
+	}
+	return options
+}
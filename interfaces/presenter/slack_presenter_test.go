@@ -0,0 +1,248 @@
+package presenter
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+	"github.com/monchh/annict-slack-bot/usecase/annictcmd"
+	"github.com/slack-go/slack"
+)
+
+var testDate = time.Date(2026, time.October, 16, 0, 0, 0, 0, jst.Location())
+
+// todaysPrograms returns n programs listed latest first, like FetchTodayPrograms.
+// Each title is titleLength characters long.
+func todaysPrograms(n, titleLength int) []*entity.Program {
+	programs := make([]*entity.Program, n)
+	for i := range programs {
+		title := fmt.Sprintf("%03d", i) + strings.Repeat("あ", titleLength-3)
+		programs[i] = &entity.Program{
+			ID:        fmt.Sprintf("program-%d", i),
+			Work:      entity.Work{ID: fmt.Sprintf("work-%d", i), Title: title},
+			Episode:   entity.Episode{ID: fmt.Sprintf("episode-%d", i), NumberText: "第1話"},
+			Channel:   entity.Channel{Name: "TOKYO MX"},
+			StartTime: testDate.Add(30*time.Hour - time.Duration(i)*time.Minute),
+		}
+	}
+	return programs
+}
+
+// morePrograms returns the "もっと見る" button of a message, or nil.
+func morePrograms(blocks []slack.Block) *slack.ButtonBlockElement {
+	for _, block := range blocks {
+		section, ok := block.(*slack.SectionBlock)
+		if ok && section.Accessory != nil && section.Accessory.ButtonElement != nil &&
+			section.Accessory.ButtonElement.ActionID == annictcmd.ACTION_SHOW_MORE_PROGRAMS {
+			return section.Accessory.ButtonElement
+		}
+	}
+	return nil
+}
+
+// countSections counts the section blocks of a message, one per program.
+func countSections(blocks []slack.Block) int {
+	n := 0
+	for _, block := range blocks {
+		if _, ok := block.(*slack.SectionBlock); ok {
+			n++
+		}
+	}
+	return n
+}
+
+// longestText returns the length in characters of the longest text of a section or context.
+func longestText(blocks []slack.Block) int {
+	longest := 0
+	count := func(text *slack.TextBlockObject) {
+		if text != nil {
+			longest = max(longest, utf8.RuneCountInString(text.Text))
+		}
+	}
+	for _, block := range blocks {
+		switch block := block.(type) {
+		case *slack.SectionBlock:
+			count(block.Text)
+			for _, field := range block.Fields {
+				count(field)
+			}
+		case *slack.ContextBlock:
+			for _, element := range block.ContextElements.Elements {
+				if text, ok := element.(*slack.TextBlockObject); ok {
+					count(text)
+				}
+			}
+		}
+	}
+	return longest
+}
+
+func TestFormatCombinedProgramsFitsLimits(t *testing.T) {
+	tests := []struct {
+		name        string
+		programs    int
+		titleLength int
+		wantMore    bool
+	}{
+		{name: "few programs fit", programs: 3, titleLength: 10},
+		{name: "block limit", programs: 60, titleLength: 5, wantMore: true},
+		{name: "long titles fit in their sections", programs: 3, titleLength: 4000},
+		{name: "long titles do not limit the message", programs: 15, titleLength: 1000},
+	}
+	p := NewSlackProgramPresenter(5)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			programs := todaysPrograms(tt.programs, tt.titleLength)
+			unwatched := todaysPrograms(5, 50)
+			blocks := p.FormatCombinedPrograms(programs, unwatched, testDate, annictcmd.ProgramsPage{Date: jst.FormatDate(testDate)})
+			if len(blocks) > maxMessageBlocks {
+				t.Errorf("message has %d blocks, want at most %d", len(blocks), maxMessageBlocks)
+			}
+			if length := longestText(p.MarkProgramsExpanded(blocks)); length > maxSectionTextLength {
+				t.Errorf("message has a text of %d characters, want at most %d", length, maxSectionTextLength)
+			}
+
+			button := morePrograms(blocks)
+			if (button != nil) != tt.wantMore {
+				t.Fatalf("more button = %v, want %v", button != nil, tt.wantMore)
+			}
+			if button == nil {
+				return
+			}
+			page, err := annictcmd.DecodeProgramsPageValue(button.Value)
+			if err != nil {
+				t.Fatalf("DecodeProgramsPageValue returned error: %v", err)
+			}
+			want := programs[countSections(blocks)-1-len(unwatched)] // Less the note and the unwatched programs
+			if page.FirstID != want.ID || !page.FirstStart.Equal(want.StartTime) {
+				t.Errorf("page points to %s at %s, want %s at %s", page.FirstID, page.FirstStart, want.ID, want.StartTime)
+			}
+		})
+	}
+}
+
+func TestFormatMoreProgramsStartsAtCursor(t *testing.T) {
+	programs := todaysPrograms(10, 10)
+	tests := []struct {
+		name      string
+		programs  []*entity.Program
+		page      annictcmd.ProgramsPage
+		wantFirst *entity.Program // First program posted, nil when none is left
+	}{
+		{
+			name:      "first program left out is still listed",
+			programs:  programs,
+			page:      annictcmd.ProgramsPage{FirstID: programs[4].ID, FirstStart: programs[4].StartTime},
+			wantFirst: programs[4],
+		},
+		{
+			name:      "earlier programs were watched meanwhile",
+			programs:  append(append([]*entity.Program{}, programs[:1]...), programs[4:]...),
+			page:      annictcmd.ProgramsPage{FirstID: programs[4].ID, FirstStart: programs[4].StartTime},
+			wantFirst: programs[4],
+		},
+		{
+			name:      "first program left out was watched meanwhile",
+			programs:  append(append([]*entity.Program{}, programs[:4]...), programs[5:]...),
+			page:      annictcmd.ProgramsPage{FirstID: programs[4].ID, FirstStart: programs[4].StartTime},
+			wantFirst: programs[5],
+		},
+		{
+			name:     "everything left out was watched",
+			programs: programs[:4],
+			page:     annictcmd.ProgramsPage{FirstID: programs[4].ID, FirstStart: programs[4].StartTime},
+		},
+	}
+	p := NewSlackProgramPresenter(5)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			messages := p.FormatMorePrograms(tt.programs, testDate, tt.page)
+			if len(messages) == 0 {
+				t.Fatal("FormatMorePrograms returned no messages")
+			}
+			first := messages[0]
+			if tt.wantFirst == nil {
+				if len(messages) != 1 || len(first) != 1 {
+					t.Errorf("got %d messages, want only the notice that nothing is left", len(messages))
+				}
+				return
+			}
+			if got := first[1].(*slack.SectionBlock).Text.Text; !strings.HasPrefix(got, "*"+tt.wantFirst.Work.Title) {
+				t.Errorf("first program posted = %q, want %s", got, tt.wantFirst.Work.Title)
+			}
+		})
+	}
+}
+
+func TestFormatMoreProgramsSplitsMessages(t *testing.T) {
+	programs := todaysPrograms(60, 200)
+	p := NewSlackProgramPresenter(5)
+	messages := p.FormatMorePrograms(programs, testDate, annictcmd.ProgramsPage{FirstID: programs[0].ID, FirstStart: programs[0].StartTime})
+	if len(messages) < 2 {
+		t.Fatalf("got %d messages, want the programs split into several", len(messages))
+	}
+	posted := 0
+	for i, blocks := range messages {
+		if len(blocks) > maxMessageBlocks || longestText(blocks) > maxSectionTextLength {
+			t.Errorf("message %d has %d blocks and a text of %d characters, want at most %d and %d", i, len(blocks), longestText(blocks), maxMessageBlocks, maxSectionTextLength)
+		}
+		posted += countSections(blocks)
+	}
+	if posted != len(programs) {
+		t.Errorf("posted %d programs, want %d", posted, len(programs))
+	}
+}
+
+func TestMarkProgramsExpanded(t *testing.T) {
+	p := NewSlackProgramPresenter(5)
+	blocks := p.FormatCombinedPrograms(todaysPrograms(60, 5), nil, testDate, annictcmd.ProgramsPage{Date: jst.FormatDate(testDate)})
+	if morePrograms(blocks) == nil {
+		t.Fatal("message has no more button")
+	}
+
+	expanded := p.MarkProgramsExpanded(blocks)
+	if morePrograms(expanded) != nil {
+		t.Error("expanded message still has the more button")
+	}
+	noted := false
+	for _, block := range expanded {
+		if section, ok := block.(*slack.SectionBlock); ok && strings.HasSuffix(section.Text.Text, programsExpandedNote) {
+			noted = true
+		}
+	}
+	if !noted {
+		t.Error("expanded message does not tell that the rest was posted")
+	}
+	if morePrograms(blocks) == nil {
+		t.Error("MarkProgramsExpanded changed the blocks passed in")
+	}
+}
+
+func TestChunkLinesCountsCharacters(t *testing.T) {
+	tests := []struct {
+		name       string
+		lines      []string
+		maxLen     int
+		wantChunks int
+	}{
+		{name: "fits in one chunk", lines: []string{"ああああ", "いいいい"}, maxLen: 9, wantChunks: 1},
+		{name: "multibyte characters are not counted as bytes", lines: []string{strings.Repeat("あ", 100), strings.Repeat("い", 100)}, maxLen: 201, wantChunks: 1},
+		{name: "split at the limit", lines: []string{"ああああ", "いいいい", "うう"}, maxLen: 9, wantChunks: 2},
+		{name: "long line stays whole", lines: []string{strings.Repeat("あ", 20)}, maxLen: 9, wantChunks: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chunks := chunkLines(tt.lines, tt.maxLen)
+			if len(chunks) != tt.wantChunks {
+				t.Errorf("got %d chunks %q, want %d", len(chunks), chunks, tt.wantChunks)
+			}
+			if joined := strings.Join(chunks, "\n"); joined != strings.Join(tt.lines, "\n") {
+				t.Errorf("chunks = %q, want all lines in order", chunks)
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package presenter
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+	"github.com/monchh/annict-slack-bot/usecase"
+	"github.com/monchh/annict-slack-bot/usecase/annictcmd"
+	"github.com/slack-go/slack"
+)
+
+// FormatSearchResults formats the works found by a search as cards.
+func (p *SlackProgramPresenter) FormatSearchResults(query *usecase.WorkSearchQuery, works []*entity.Work) []slack.Block {
+	var conditions []string
+	if query.Title != "" {
+		conditions = append(conditions, fmt.Sprintf("「%s」", query.Title))
+	}
+	for _, season := range query.Seasons {
+		conditions = append(conditions, formatSeason(season))
+	}
+	headerText := fmt.Sprintf(":mag: %s の検索結果", strings.Join(conditions, " "))
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, headerText, true, false)),
+	}
+	if len(works) == 0 {
+		return append(blocks, slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, "作品は見つかりませんでした。", false, false),
+			nil, nil,
+		))
+	}
+
+	order := "新しいシーズン順"
+	if query.OrderByWatchers {
+		order = "視聴者数順"
+	}
+	blocks = append(blocks, slack.NewContextBlock("",
+		slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("%d 件 (%s)", len(works), order), false, false),
+	))
+	for _, work := range works {
+		blocks = append(blocks, slack.NewDividerBlock())
+		blocks = append(blocks, formatWorkCard(work)...)
+	}
+	return blocks
+}
+
+// FormatSeasonLineup formats one page of a season lineup as ranked lines with the
+// viewer's status, followed by buttons to the previous and next page.
+// cursors are the cursors of the pages before this one.
+func (p *SlackProgramPresenter) FormatSeasonLineup(lineup *usecase.SeasonLineupOutput, cursors []string, slackUserID string) []slack.Block {
+	seasonLabels := make([]string, len(lineup.Seasons))
+	for i, season := range lineup.Seasons {
+		seasonLabels[i] = formatSeason(season)
+	}
+	headerText := fmt.Sprintf(":tv: %s の作品", strings.Join(seasonLabels, " / "))
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, headerText, true, false)),
+		slack.NewContextBlock("",
+			slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("視聴者数順 • %d ページ目 • ステータスは <@%s> のライブラリです", len(cursors)+1, slackUserID), false, false),
+		),
+	}
+	if len(lineup.Page.Works) == 0 {
+		return append(blocks, slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, "作品は見つかりませんでした。", false, false),
+			nil, nil,
+		))
+	}
+
+	offset := len(cursors) * usecase.SeasonLineupPageSize
+	lines := make([]string, 0, len(lineup.Page.Works))
+	for i, work := range lineup.Page.Works {
+		title := fmt.Sprintf("*%s*", work.Title)
+		if work.OfficialSiteURL != nil && *work.OfficialSiteURL != "" {
+			title = fmt.Sprintf("*<%s|%s>*", *work.OfficialSiteURL, work.Title)
+		}
+		line := fmt.Sprintf("`%d.` %s", offset+i+1, title)
+		if work.Media != "" {
+			line += fmt.Sprintf(" %s", mediaLabel(work.Media))
+		}
+		line += fmt.Sprintf(" • :busts_in_silhouette: %s", formatCount(work.WatchersCount))
+		if work.Status != "" {
+			line += fmt.Sprintf(" • :bookmark: %s", watchStatusLabel(work.Status))
+		}
+		lines = append(lines, line)
+	}
+	for _, chunk := range chunkLines(lines, maxSectionTextLength) {
+		blocks = append(blocks, slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, chunk, false, false),
+			nil, nil,
+		))
+	}
+
+	var buttons []slack.BlockElement
+	if len(cursors) > 0 {
+		buttons = append(buttons, slack.NewButtonBlockElement(
+			annictcmd.ACTION_SEASON_PREV_PAGE,
+			annictcmd.EncodeSeasonPageValue(lineup.Seasons, cursors[:len(cursors)-1]),
+			slack.NewTextBlockObject(slack.PlainTextType, "◀ 前のページ", true, false),
+		))
+	}
+	if lineup.Page.HasNextPage {
+		buttons = append(buttons, slack.NewButtonBlockElement(
+			annictcmd.ACTION_SEASON_NEXT_PAGE,
+			annictcmd.EncodeSeasonPageValue(lineup.Seasons, append(append([]string{}, cursors...), lineup.Page.EndCursor)),
+			slack.NewTextBlockObject(slack.PlainTextType, "次のページ ▶", true, false),
+		))
+	}
+	if len(buttons) > 0 {
+		blocks = append(blocks, slack.NewActionBlock("", buttons...))
+	}
+	return blocks
+}
+
+// FormatSeasonPreview formats the "新クール開始" digest: the WANNA_WATCH works of the
+// next season with their first broadcast, or 放送日未定 when none is announced yet.
+func (p *SlackProgramPresenter) FormatSeasonPreview(preview *usecase.SeasonPreviewOutput) []slack.Block {
+	headerText := fmt.Sprintf(":sparkles: 新クール開始: %s", formatSeason(preview.Season))
+	blocks := []slack.Block{
+		slack.NewHeaderBlock(slack.NewTextBlockObject(slack.PlainTextType, headerText, true, false)),
+		slack.NewContextBlock("",
+			slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("%s から始まるシーズンで「見たい」に登録している作品です", formatShortDate(preview.StartsAt)), false, false),
+		),
+	}
+	if len(preview.Programs) == 0 {
+		return append(blocks, slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, fmt.Sprintf("「見たい」に登録した作品はまだありません。`%s %s` で探せます。", annictcmd.COMMAND_PREFIX, annictcmd.CMD_SEASON), false, false),
+			nil, nil,
+		))
+	}
+
+	lines := make([]string, 0, len(preview.Programs))
+	for _, program := range preview.Programs {
+		title := fmt.Sprintf("*%s*", program.Work.Title)
+		if program.Work.OfficialSiteURL != nil && *program.Work.OfficialSiteURL != "" {
+			title = fmt.Sprintf("*<%s|%s>*", *program.Work.OfficialSiteURL, program.Work.Title)
+		}
+		if program.StartTime.IsZero() {
+			lines = append(lines, fmt.Sprintf("`放送日未定` %s", title))
+			continue
+		}
+		line := fmt.Sprintf("`%s %s` %s", formatShortDate(jst.BroadcastDay(program.StartTime)), jst.FormatTime(program.StartTime), title)
+		if program.Channel.Name != "" {
+			line += fmt.Sprintf(" (%s)", program.Channel.Name)
+		}
+		lines = append(lines, line)
+	}
+	for _, chunk := range chunkLines(lines, maxSectionTextLength) {
+		blocks = append(blocks, slack.NewSectionBlock(
+			slack.NewTextBlockObject(slack.MarkdownType, chunk, false, false),
+			nil, nil,
+		))
+	}
+	return blocks
+}
+
+// formatWorkCard formats a work as a section with its details and image, followed by
+// a button adding it to the library as WANNA_WATCH.
+func formatWorkCard(work *entity.Work) []slack.Block {
+	var textBuilder strings.Builder
+	if work.OfficialSiteURL != nil && *work.OfficialSiteURL != "" {
+		textBuilder.WriteString(fmt.Sprintf("*<%s|%s>*\n", *work.OfficialSiteURL, work.Title))
+	} else {
+		textBuilder.WriteString(fmt.Sprintf("*%s*\n", work.Title))
+	}
+
+	var details []string
+	if work.Season != "" {
+		details = append(details, formatSeason(work.Season))
+	}
+	if work.Media != "" {
+		details = append(details, mediaLabel(work.Media))
+	}
+	if work.EpisodesCount > 0 {
+		details = append(details, fmt.Sprintf("全%d話", work.EpisodesCount))
+	}
+	if len(details) > 0 {
+		textBuilder.WriteString(strings.Join(details, " • ") + "\n")
+	}
+
+	satisfaction := "-"
+	if work.SatisfactionRate != nil {
+		satisfaction = fmt.Sprintf("%.1f%%", *work.SatisfactionRate)
+	}
+	textBuilder.WriteString(fmt.Sprintf(":star: 満足度 %s • :busts_in_silhouette: %s 人が視聴", satisfaction, formatCount(work.WatchersCount)))
+	if work.Status != "" {
+		textBuilder.WriteString(fmt.Sprintf("\n:bookmark: ライブラリ: %s", watchStatusLabel(work.Status)))
+	}
+
+	var accessory *slack.Accessory
+	if work.ImageURL != nil {
+		accessory = slack.NewAccessory(slack.NewImageBlockElement(*work.ImageURL, fmt.Sprintf("%s image", work.Title)))
+	}
+	section := slack.NewSectionBlock(slack.NewTextBlockObject(slack.MarkdownType, textBuilder.String(), false, false), nil, accessory)
+
+	button := slack.NewButtonBlockElement(
+		annictcmd.ACTION_ADD_WANNA_WATCH,
+		work.ID,
+		slack.NewTextBlockObject(slack.PlainTextType, "見たいに追加", true, false),
+	)
+	if work.Status == "" {
+		button = button.WithStyle(slack.StylePrimary)
+	}
+	return []slack.Block{section, slack.NewActionBlock("", button)}
+}
+
+// formatSeason formats an Annict season such as "2026-autumn" as "2026年秋".
+func formatSeason(season string) string {
+	year, name, ok := strings.Cut(season, "-")
+	if !ok {
+		return season
+	}
+	switch name {
+	case "winter":
+		return year + "年冬"
+	case "spring":
+		return year + "年春"
+	case "summer":
+		return year + "年夏"
+	case "autumn":
+		return year + "年秋"
+	default:
+		return season
+	}
+}
+
+// mediaLabel returns the Japanese label of Annict's media type.
+func mediaLabel(media string) string {
+	switch media {
+	case "TV":
+		return "TV"
+	case "OVA":
+		return "OVA"
+	case "MOVIE":
+		return "映画"
+	case "WEB":
+		return "Web"
+	case "OTHER":
+		return "その他"
+	default:
+		return media
+	}
+}
+
+// formatCount formats a number with thousands separators, e.g. "12,345".
+func formatCount(n int) string {
+	s := strconv.Itoa(n)
+	for i := len(s) - 3; i > 0; i -= 3 {
+		s = s[:i] + "," + s[i:]
+	}
+	return s
+}
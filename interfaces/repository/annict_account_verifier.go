@@ -0,0 +1,44 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/infrastructure/annict"
+	"github.com/monchh/annict-slack-bot/usecase"
+)
+
+// ClientFactory creates an Annict API client authenticated with the access token.
+type ClientFactory func(token string) *annict.Client
+
+// annictAccountVerifier implements the AccountVerifier interface using the Annict GraphQL API.
+type annictAccountVerifier struct {
+	newClient ClientFactory
+	logger    *slog.Logger
+}
+
+// NewAnnictAccountVerifier creates a verifier that queries Annict with the token being linked.
+func NewAnnictAccountVerifier(newClient ClientFactory, logger *slog.Logger) usecase.AccountVerifier {
+	return &annictAccountVerifier{
+		newClient: newClient,
+		logger:    logger,
+	}
+}
+
+func (v *annictAccountVerifier) FetchViewer(ctx context.Context, token string) (*entity.Viewer, error) {
+	v.logger.DebugContext(ctx, "Fetching viewer from Annict API")
+	resp, err := v.newClient(token).GetViewer(ctx)
+	if err != nil {
+		v.logger.ErrorContext(ctx, "Failed to call GetViewer", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("annictAPIClient.GetViewer failed: %w", err)
+	}
+	if resp == nil || resp.Viewer == nil {
+		return nil, fmt.Errorf("annictAPIClient.GetViewer returned no viewer")
+	}
+	return &entity.Viewer{
+		Username: resp.Viewer.GetUsername(),
+		Name:     resp.Viewer.GetName(),
+	}, nil
+}
@@ -6,6 +6,7 @@ import (
 	"log/slog"
 	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/monchh/annict-slack-bot/domain/entity"
@@ -14,45 +15,138 @@ import (
 	"github.com/monchh/annict-slack-bot/usecase"
 )
 
+// Default pagination limits used when PaginationOptions leaves them unset.
+const (
+	defaultPageSize = 50
+	defaultMaxPages = 10
+)
+
+// PaginationOptions controls how connection queries are paged through.
+type PaginationOptions struct {
+	PageSize int // Nodes requested per page
+	MaxPages int // Upper bound of pages fetched per call
+}
+
+func (o PaginationOptions) withDefaults() PaginationOptions {
+	if o.PageSize <= 0 {
+		o.PageSize = defaultPageSize
+	}
+	if o.MaxPages <= 0 {
+		o.MaxPages = defaultMaxPages
+	}
+	return o
+}
+
 // annictRepository implements the ProgramRepository interface using the Annict GraphQL API.
 type annictRepository struct {
 	annictAPIClient *annict.Client
 	logger          *slog.Logger
+	pagination      PaginationOptions
 }
 
 // NewAnnictRepository creates a new repository instance.
-func NewAnnictRepository(client *annict.Client, logger *slog.Logger) usecase.ProgramRepository {
+func NewAnnictRepository(client *annict.Client, logger *slog.Logger, pagination PaginationOptions) usecase.ProgramRepository {
 	return &annictRepository{
 		annictAPIClient: client,
 		logger:          logger,
+		pagination:      pagination.withDefaults(),
 	}
 }
 
-func (r *annictRepository) FetchTodayPrograms(ctx context.Context) ([]*entity.Program, error) {
-	r.logger.DebugContext(ctx, "Fetching unwatched programs from Annict API")
-	resp, err := r.annictAPIClient.GetPrograms(ctx)
-	if err != nil {
-		r.logger.ErrorContext(ctx, "Failed to call GetPrograms", slog.String("error", err.Error()))
-		return nil, fmt.Errorf("annictAPIClient.GetPrograms failed: %w", err)
+// NewAnnictRecordRepository creates a repository instance for writing watch records.
+func NewAnnictRecordRepository(client *annict.Client, logger *slog.Logger) usecase.RecordRepository {
+	return &annictRepository{
+		annictAPIClient: client,
+		logger:          logger,
+		pagination:      PaginationOptions{}.withDefaults(),
+	}
+}
+
+// NewAnnictStatusRepository creates a repository instance for reading and changing watch statuses.
+func NewAnnictStatusRepository(client *annict.Client, logger *slog.Logger) usecase.StatusRepository {
+	return &annictRepository{
+		annictAPIClient: client,
+		logger:          logger,
+		pagination:      PaginationOptions{}.withDefaults(),
 	}
+}
+
+// NewAnnictSeasonPreviewRepository creates a repository instance for reading the next season's plans.
+func NewAnnictSeasonPreviewRepository(client *annict.Client, logger *slog.Logger, pagination PaginationOptions) usecase.SeasonPreviewRepository {
+	return &annictRepository{
+		annictAPIClient: client,
+		logger:          logger,
+		pagination:      pagination.withDefaults(),
+	}
+}
 
-	if resp == nil || resp.Viewer == nil || resp.Viewer.Programs == nil {
-		r.logger.InfoContext(ctx, "No unwatched programs data returned from Annict API or viewer/programs is nil")
-		return []*entity.Program{}, nil
+// NewAnnictWatchStatsRepository creates a repository instance for reading watch statistics.
+func NewAnnictWatchStatsRepository(client *annict.Client, logger *slog.Logger) usecase.WatchStatsRepository {
+	return &annictRepository{
+		annictAPIClient: client,
+		logger:          logger,
+		pagination:      PaginationOptions{}.withDefaults(),
 	}
+}
+
+// NewAnnictWorkSearchRepository creates a repository instance for searching works.
+func NewAnnictWorkSearchRepository(client *annict.Client, logger *slog.Logger) usecase.WorkSearchRepository {
+	return &annictRepository{
+		annictAPIClient: client,
+		logger:          logger,
+		pagination:      PaginationOptions{}.withDefaults(),
+	}
+}
+
+// FetchTodayPrograms pages through the viewer's unwatched programs.
+// Annict returns them newest first, so paging stops as soon as a page reaches
+// programs that started before the current broadcast day.
+func (r *annictRepository) FetchTodayPrograms(ctx context.Context) ([]*entity.Program, error) {
+	windowStart := jst.Today()
+	r.logger.DebugContext(ctx, "Fetching unwatched programs from Annict API", slog.Time("since", windowStart))
 
+	first := int64(r.pagination.PageSize)
+	var after *string
 	var programs []*entity.Program
-	for _, programNode := range resp.Viewer.Programs.Nodes {
-		if programNode == nil {
-			continue
+	for page := 1; ; page++ {
+		resp, err := r.annictAPIClient.GetPrograms(ctx, &first, after)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Failed to call GetPrograms", slog.Int("page", page), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("annictAPIClient.GetPrograms failed: %w", err)
 		}
 
-		// mapping *annict.GetPrograms_Viewer_Programs_Nodes to *entity.Program
-		domainProgram := mapAnnictProgramToDomainProgram(programNode)
-		if domainProgram == nil {
-			continue
+		if resp == nil || resp.Viewer == nil || resp.Viewer.Programs == nil {
+			r.logger.InfoContext(ctx, "No unwatched programs data returned from Annict API or viewer/programs is nil", slog.Int("page", page))
+			break
+		}
+
+		passedWindow := false
+		for _, programNode := range resp.Viewer.Programs.Nodes {
+			if programNode == nil {
+				continue
+			}
+
+			// mapping *annict.GetPrograms_Viewer_Programs_Nodes to *entity.Program
+			domainProgram := mapAnnictProgramToDomainProgram(programNode)
+			if domainProgram == nil {
+				continue
+			}
+			if !domainProgram.StartTime.IsZero() && domainProgram.StartTime.Before(windowStart) {
+				passedWindow = true
+				continue
+			}
+			programs = append(programs, domainProgram)
+		}
+
+		pageInfo := resp.Viewer.Programs.PageInfo
+		if passedWindow || !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			break
 		}
-		programs = append(programs, domainProgram)
+		if page >= r.pagination.MaxPages {
+			r.logger.WarnContext(ctx, "Reached page cap while fetching unwatched programs, remaining pages are skipped", slog.Int("maxPages", r.pagination.MaxPages))
+			break
+		}
+		after = pageInfo.EndCursor
 	}
 	r.logger.InfoContext(ctx, "Successfully fetched unwatched programs", slog.Int("count", len(programs)))
 	return programs, nil
@@ -62,22 +156,28 @@ func mapAnnictProgramToDomainProgram(node *annict.GetPrograms_Viewer_Programs_No
 	if node == nil {
 		return nil
 	}
-	prog := &entity.Program{}
+	prog := &entity.Program{ID: node.GetID(), Rebroadcast: node.GetRebroadcast()}
+	if node.GetState() != nil {
+		prog.State = entity.ProgramState(*node.GetState())
+	}
 	if !reflect.ValueOf(node.Work).IsZero() {
 		prog.Work.Title = node.Work.GetTitle()
+		prog.Work.AnnictID = node.Work.GetAnnictID()
+		if node.Work.GetSyobocalTid() != nil {
+			prog.Work.SyobocalTID = *node.Work.GetSyobocalTid()
+		}
 		if node.Work.GetOfficialSiteURL() != nil && *node.Work.GetOfficialSiteURL() != "" {
 			prog.Work.OfficialSiteURL = node.Work.GetOfficialSiteURL()
 		}
 		if node.Work.Image != nil {
-			// Prefer RecommendedImageURL, if not available use FacebookOgImageURL
-			if node.Work.Image.GetRecommendedImageURL() != nil && *node.Work.Image.GetRecommendedImageURL() != "" {
-				prog.Work.ImageURL = node.Work.Image.GetRecommendedImageURL()
-			} else if node.Work.Image.GetFacebookOgImageURL() != nil {
-				prog.Work.ImageURL = node.Work.Image.GetFacebookOgImageURL()
+			prog.Work.ImageCandidates = imageCandidates(node.Work.Image.GetRecommendedImageURL(), node.Work.Image.GetFacebookOgImageURL())
+			if len(prog.Work.ImageCandidates) > 0 {
+				prog.Work.ImageURL = &prog.Work.ImageCandidates[0]
 			}
 		}
 	}
 	if !reflect.ValueOf(node.Episode).IsZero() {
+		prog.Episode.ID = node.Episode.GetID()
 		if node.Episode.GetNumberText() != nil && *node.Episode.GetNumberText() != "" {
 			prog.Episode.NumberText = *node.Episode.GetNumberText()
 		} else if node.Episode.GetNumber() != nil {
@@ -90,7 +190,9 @@ func mapAnnictProgramToDomainProgram(node *annict.GetPrograms_Viewer_Programs_No
 		}
 	}
 	if !reflect.ValueOf(node.Channel).IsZero() {
+		prog.Channel.AnnictID = node.Channel.GetAnnictID()
 		prog.Channel.Name = node.Channel.GetName()
+		prog.Channel.GroupName = node.Channel.GetChannelGroup().GetName()
 	}
 	if node.StartedAt != "" {
 		parsedTime, err := time.Parse(time.RFC3339, node.StartedAt)
@@ -101,35 +203,20 @@ func mapAnnictProgramToDomainProgram(node *annict.GetPrograms_Viewer_Programs_No
 	return prog
 }
 
+// FetchLibraryEntries returns the viewer's WATCHING and WANNA_WATCH entries of the current
+// season, plus WATCHING entries of any season that still have an episode to watch:
+// continuing shows would otherwise drop out of the list when a new season starts.
 func (r *annictRepository) FetchLibraryEntries(ctx context.Context) ([]*entity.Program, error) {
-	targetSeason := jst.GetAnnictSeason(jst.Now())
-	seasons := []string{targetSeason}
-	r.logger.DebugContext(ctx, "Fetching library entries from Annict API", slog.String("season", targetSeason))
-
-	resp, err := r.annictAPIClient.GetLibraryEntries(ctx, seasons)
+	programs, err := r.fetchLibraryEntries(ctx, []annict.StatusState{annict.StatusStateWatching, annict.StatusStateWannaWatch}, []string{jst.GetAnnictSeason(jst.Now())})
 	if err != nil {
-		r.logger.ErrorContext(ctx, "Failed to call GetLibraryEntries", slog.String("error", err.Error()))
-		return nil, fmt.Errorf("annictAPIClient.GetLibraryEntries failed: %w", err)
+		return nil, err
 	}
-
-	if resp == nil || resp.Viewer == nil || resp.Viewer.LibraryEntries == nil {
-		r.logger.InfoContext(ctx, "No library entries data returned from Annict API or viewer/libraryEntries is nil")
-		return []*entity.Program{}, nil
+	watching, err := r.fetchLibraryEntries(ctx, []annict.StatusState{annict.StatusStateWatching}, nil)
+	if err != nil {
+		return nil, err
 	}
+	programs, added := appendContinuing(programs, watching)
 
-	var programs []*entity.Program
-	for _, entryNode := range resp.Viewer.LibraryEntries.Nodes {
-		if entryNode == nil {
-			continue
-		}
-		// mapping *annict.GetLibraryEntries_Viewer_LibraryEntries_Nodes to entity.Program
-		domainProgram := mapAnnictLibraryEntriesToDomainProgram(entryNode)
-		if domainProgram == nil {
-			continue
-		}
-
-		programs = append(programs, domainProgram)
-	}
 	sort.Slice(programs, func(i, j int) bool {
 		if programs[i].StartTime.IsZero() {
 			return true
@@ -139,7 +226,78 @@ func (r *annictRepository) FetchLibraryEntries(ctx context.Context) ([]*entity.P
 		}
 		return programs[i].StartTime.After(programs[j].StartTime)
 	})
-	r.logger.InfoContext(ctx, "Successfully fetched and filtered library entries for today", slog.Int("count", len(programs)))
+	r.logger.InfoContext(ctx, "Successfully fetched and filtered library entries for today", slog.Int("count", len(programs)), slog.Int("continuing", added))
+	return programs, nil
+}
+
+// appendContinuing adds the WATCHING entries that have a next episode and are not listed yet.
+// It returns the entries and how many were added.
+func appendContinuing(programs, watching []*entity.Program) ([]*entity.Program, int) {
+	seen := make(map[string]bool, len(programs))
+	for _, p := range programs {
+		seen[p.Work.ID] = true
+	}
+	added := 0
+	for _, p := range watching {
+		if seen[p.Work.ID] || p.Episode.ID == "" {
+			continue
+		}
+		seen[p.Work.ID] = true
+		programs = append(programs, p)
+		added++
+	}
+	return programs, added
+}
+
+// FetchSeasonWannaWatch returns the viewer's WANNA_WATCH entries of the season.
+// Their next program is the first broadcast on the viewer's channels, if already announced.
+func (r *annictRepository) FetchSeasonWannaWatch(ctx context.Context, season string) ([]*entity.Program, error) {
+	return r.fetchLibraryEntries(ctx, []annict.StatusState{annict.StatusStateWannaWatch}, []string{season})
+}
+
+// fetchLibraryEntries pages through the viewer's library entries in the states and seasons.
+// nil seasons means every season.
+func (r *annictRepository) fetchLibraryEntries(ctx context.Context, states []annict.StatusState, seasons []string) ([]*entity.Program, error) {
+	r.logger.DebugContext(ctx, "Fetching library entries from Annict API", slog.Any("states", states), slog.Any("seasons", seasons))
+
+	first := int64(r.pagination.PageSize)
+	var after *string
+	var programs []*entity.Program
+	for page := 1; ; page++ {
+		resp, err := r.annictAPIClient.GetLibraryEntries(ctx, states, seasons, &first, after)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Failed to call GetLibraryEntries", slog.Int("page", page), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("annictAPIClient.GetLibraryEntries failed: %w", err)
+		}
+
+		if resp == nil || resp.Viewer == nil || resp.Viewer.LibraryEntries == nil {
+			r.logger.InfoContext(ctx, "No library entries data returned from Annict API or viewer/libraryEntries is nil", slog.Int("page", page))
+			break
+		}
+
+		for _, entryNode := range resp.Viewer.LibraryEntries.Nodes {
+			if entryNode == nil {
+				continue
+			}
+			// mapping *annict.GetLibraryEntries_Viewer_LibraryEntries_Nodes to entity.Program
+			domainProgram := mapAnnictLibraryEntriesToDomainProgram(entryNode)
+			if domainProgram == nil {
+				continue
+			}
+
+			programs = append(programs, domainProgram)
+		}
+
+		pageInfo := resp.Viewer.LibraryEntries.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			break
+		}
+		if page >= r.pagination.MaxPages {
+			r.logger.WarnContext(ctx, "Reached page cap while fetching library entries, remaining pages are skipped", slog.Int("maxPages", r.pagination.MaxPages))
+			break
+		}
+		after = pageInfo.EndCursor
+	}
 	return programs, nil
 }
 
@@ -149,20 +307,27 @@ func mapAnnictLibraryEntriesToDomainProgram(node *annict.GetLibraryEntries_Viewe
 	}
 	prog := &entity.Program{}
 	if !reflect.ValueOf(node.Work).IsZero() {
+		prog.Work.ID = node.Work.GetID()
+		prog.Work.AnnictID = node.Work.GetAnnictID()
+		if node.Work.GetSyobocalTid() != nil {
+			prog.Work.SyobocalTID = *node.Work.GetSyobocalTid()
+		}
 		prog.Work.Title = node.Work.GetTitle()
+		if node.Work.GetViewerStatusState() != nil {
+			prog.Work.Status = entity.WatchStatus(*node.Work.GetViewerStatusState())
+		}
 		if node.Work.GetOfficialSiteURL() != nil && *node.Work.GetOfficialSiteURL() != "" {
 			prog.Work.OfficialSiteURL = node.Work.GetOfficialSiteURL()
 		}
 		if node.Work.Image != nil {
-			// Prefer RecommendedImageURL, if not available use FacebookOgImageURL
-			if node.Work.Image.GetRecommendedImageURL() != nil && *node.Work.Image.GetRecommendedImageURL() != "" {
-				prog.Work.ImageURL = node.Work.Image.GetRecommendedImageURL()
-			} else if node.Work.Image.GetFacebookOgImageURL() != nil {
-				prog.Work.ImageURL = node.Work.Image.GetFacebookOgImageURL()
+			prog.Work.ImageCandidates = imageCandidates(node.Work.Image.GetRecommendedImageURL(), node.Work.Image.GetFacebookOgImageURL())
+			if len(prog.Work.ImageCandidates) > 0 {
+				prog.Work.ImageURL = &prog.Work.ImageCandidates[0]
 			}
 		}
 	}
 	if node.NextEpisode != nil {
+		prog.Episode.ID = node.NextEpisode.GetID()
 		if node.NextEpisode.GetNumberText() != nil && *node.NextEpisode.GetNumberText() != "" {
 			prog.Episode.NumberText = *node.NextEpisode.GetNumberText()
 		} else {
@@ -174,7 +339,9 @@ func mapAnnictLibraryEntriesToDomainProgram(node *annict.GetLibraryEntries_Viewe
 	}
 	if node.NextProgram != nil {
 		if !reflect.ValueOf(node.NextProgram.Channel).IsZero() {
+			prog.Channel.AnnictID = node.NextProgram.Channel.GetAnnictID()
 			prog.Channel.Name = node.NextProgram.Channel.GetName()
+			prog.Channel.GroupName = node.NextProgram.Channel.GetChannelGroup().GetName()
 		}
 		if node.NextProgram.StartedAt != "" {
 			parsedTime, err := time.Parse(time.RFC3339, node.NextProgram.StartedAt)
@@ -185,3 +352,205 @@ func mapAnnictLibraryEntriesToDomainProgram(node *annict.GetLibraryEntries_Viewe
 	}
 	return prog
 }
+
+func (r *annictRepository) CreateRecord(ctx context.Context, episodeID string) error {
+	r.logger.DebugContext(ctx, "Creating record on Annict API", slog.String("episodeID", episodeID))
+	resp, err := r.annictAPIClient.CreateRecord(ctx, episodeID)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to call CreateRecord", slog.String("error", err.Error()))
+		return fmt.Errorf("annictAPIClient.CreateRecord failed: %w", err)
+	}
+	if resp == nil || resp.CreateRecord == nil || resp.CreateRecord.Record == nil {
+		return fmt.Errorf("annictAPIClient.CreateRecord returned no record for episode %s", episodeID)
+	}
+	r.logger.InfoContext(ctx, "Successfully created record", slog.String("recordID", resp.CreateRecord.Record.GetID()))
+	return nil
+}
+
+func (r *annictRepository) FetchLibraryWorks(ctx context.Context) ([]*entity.Work, error) {
+	states := []annict.StatusState{
+		annict.StatusStateWatching,
+		annict.StatusStateWannaWatch,
+		annict.StatusStateOnHold,
+		annict.StatusStateStopWatching,
+	}
+	r.logger.DebugContext(ctx, "Fetching library works from Annict API")
+
+	first := int64(r.pagination.PageSize)
+	var after *string
+	works := []*entity.Work{}
+	for page := 1; ; page++ {
+		resp, err := r.annictAPIClient.GetLibraryWorks(ctx, states, &first, after)
+		if err != nil {
+			r.logger.ErrorContext(ctx, "Failed to call GetLibraryWorks", slog.Int("page", page), slog.String("error", err.Error()))
+			return nil, fmt.Errorf("annictAPIClient.GetLibraryWorks failed: %w", err)
+		}
+
+		if resp == nil || resp.Viewer == nil || resp.Viewer.LibraryEntries == nil {
+			r.logger.InfoContext(ctx, "No library works data returned from Annict API or viewer/libraryEntries is nil", slog.Int("page", page))
+			break
+		}
+
+		for _, entryNode := range resp.Viewer.LibraryEntries.Nodes {
+			if entryNode == nil {
+				continue
+			}
+			work := &entity.Work{
+				ID:    entryNode.Work.GetID(),
+				Title: entryNode.Work.GetTitle(),
+			}
+			if entryNode.Work.GetViewerStatusState() != nil {
+				work.Status = entity.WatchStatus(*entryNode.Work.GetViewerStatusState())
+			}
+			works = append(works, work)
+		}
+
+		pageInfo := resp.Viewer.LibraryEntries.PageInfo
+		if !pageInfo.HasNextPage || pageInfo.EndCursor == nil {
+			break
+		}
+		if page >= r.pagination.MaxPages {
+			r.logger.WarnContext(ctx, "Reached page cap while fetching library works, remaining pages are skipped", slog.Int("maxPages", r.pagination.MaxPages))
+			break
+		}
+		after = pageInfo.EndCursor
+	}
+	r.logger.InfoContext(ctx, "Successfully fetched library works", slog.Int("count", len(works)))
+	return works, nil
+}
+
+func (r *annictRepository) UpdateStatus(ctx context.Context, workID string, status entity.WatchStatus) (*entity.Work, error) {
+	r.logger.DebugContext(ctx, "Updating status on Annict API", slog.String("workID", workID), slog.String("status", string(status)))
+	state := annict.StatusState(status)
+	if !state.IsValid() {
+		return nil, fmt.Errorf("invalid status state: %s", status)
+	}
+	resp, err := r.annictAPIClient.UpdateStatus(ctx, workID, state)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to call UpdateStatus", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("annictAPIClient.UpdateStatus failed: %w", err)
+	}
+	if resp == nil || resp.UpdateStatus == nil || resp.UpdateStatus.Work == nil {
+		return nil, fmt.Errorf("annictAPIClient.UpdateStatus returned no work for %s", workID)
+	}
+
+	updated := resp.UpdateStatus.Work
+	work := &entity.Work{
+		ID:     updated.GetID(),
+		Title:  updated.GetTitle(),
+		Status: status,
+	}
+	if updated.GetViewerStatusState() != nil {
+		work.Status = entity.WatchStatus(*updated.GetViewerStatusState())
+	}
+	r.logger.InfoContext(ctx, "Successfully updated status", slog.String("workID", work.ID), slog.String("status", string(work.Status)))
+	return work, nil
+}
+
+func (r *annictRepository) SearchWorks(ctx context.Context, query *usecase.WorkSearchQuery) (*usecase.WorkSearchPage, error) {
+	r.logger.DebugContext(ctx, "Searching works on Annict API",
+		slog.String("title", query.Title), slog.Any("seasons", query.Seasons), slog.Bool("orderByWatchers", query.OrderByWatchers))
+	var titles []string
+	if query.Title != "" {
+		titles = []string{query.Title}
+	}
+	orderBy := &annict.WorkOrder{Field: annict.WorkOrderFieldSeason, Direction: annict.OrderDirectionDesc}
+	if query.OrderByWatchers {
+		orderBy.Field = annict.WorkOrderFieldWatchersCount
+	}
+	first := int64(query.Limit)
+	var after *string
+	if query.After != "" {
+		after = &query.After
+	}
+
+	resp, err := r.annictAPIClient.SearchWorks(ctx, titles, query.Seasons, orderBy, &first, after)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to call SearchWorks", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("annictAPIClient.SearchWorks failed: %w", err)
+	}
+	page := &usecase.WorkSearchPage{}
+	if resp == nil || resp.SearchWorks == nil {
+		r.logger.InfoContext(ctx, "No search results returned from Annict API or searchWorks is nil")
+		return page, nil
+	}
+
+	for _, node := range resp.SearchWorks.Nodes {
+		if node == nil {
+			continue
+		}
+		page.Works = append(page.Works, mapAnnictSearchWorkToDomainWork(node))
+	}
+	if pageInfo := resp.SearchWorks.GetPageInfo(); pageInfo.HasNextPage && pageInfo.EndCursor != nil {
+		page.HasNextPage = true
+		page.EndCursor = *pageInfo.EndCursor
+	}
+	r.logger.InfoContext(ctx, "Successfully searched works", slog.Int("count", len(page.Works)))
+	return page, nil
+}
+
+func mapAnnictSearchWorkToDomainWork(node *annict.SearchWorks_SearchWorks_Nodes) *entity.Work {
+	work := &entity.Work{
+		ID:               node.GetID(),
+		AnnictID:         node.GetAnnictID(),
+		Title:            node.GetTitle(),
+		Media:            string(*node.GetMedia()),
+		EpisodesCount:    int(node.GetEpisodesCount()),
+		SatisfactionRate: node.GetSatisfactionRate(),
+		WatchersCount:    int(node.GetWatchersCount()),
+	}
+	if node.GetSyobocalTid() != nil {
+		work.SyobocalTID = *node.GetSyobocalTid()
+	}
+	if node.GetOfficialSiteURL() != nil && *node.GetOfficialSiteURL() != "" {
+		work.OfficialSiteURL = node.GetOfficialSiteURL()
+	}
+	// NO_STATE means the work is not in the viewer's library.
+	if state := node.GetViewerStatusState(); state != nil && *state != annict.StatusStateNoState {
+		work.Status = entity.WatchStatus(*state)
+	}
+	if node.GetSeasonYear() != nil && node.GetSeasonName() != nil {
+		work.Season = fmt.Sprintf("%d-%s", *node.GetSeasonYear(), strings.ToLower(string(*node.GetSeasonName())))
+	}
+	if node.Image != nil {
+		work.ImageCandidates = imageCandidates(node.Image.GetRecommendedImageURL(), node.Image.GetFacebookOgImageURL())
+		if len(work.ImageCandidates) > 0 {
+			work.ImageURL = &work.ImageCandidates[0]
+		}
+	}
+	return work
+}
+
+// imageCandidates lists the non-empty image URLs in order of preference:
+// RecommendedImageURL first, then FacebookOgImageURL.
+func imageCandidates(urls ...*string) []string {
+	var candidates []string
+	for _, u := range urls {
+		if u != nil && *u != "" {
+			candidates = append(candidates, *u)
+		}
+	}
+	return candidates
+}
+
+// FetchWatchStats returns the counts of the viewer's library by status and of their records.
+func (r *annictRepository) FetchWatchStats(ctx context.Context) (*entity.WatchStats, error) {
+	r.logger.DebugContext(ctx, "Fetching watch statistics from Annict API")
+	resp, err := r.annictAPIClient.GetViewer(ctx)
+	if err != nil {
+		r.logger.ErrorContext(ctx, "Failed to call GetViewer", slog.String("error", err.Error()))
+		return nil, fmt.Errorf("annictAPIClient.GetViewer failed: %w", err)
+	}
+	if resp == nil || resp.Viewer == nil {
+		return nil, fmt.Errorf("annictAPIClient.GetViewer returned no viewer")
+	}
+	viewer := resp.Viewer
+	return &entity.WatchStats{
+		Watching:     int(viewer.GetWatchingCount()),
+		WannaWatch:   int(viewer.GetWannaWatchCount()),
+		Watched:      int(viewer.GetWatchedCount()),
+		OnHold:       int(viewer.GetOnHoldCount()),
+		StopWatching: int(viewer.GetStopWatchingCount()),
+		Records:      int(viewer.GetRecordsCount()),
+	}, nil
+}
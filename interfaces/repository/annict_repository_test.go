@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+func libraryEntry(workID, nextEpisodeID string) *entity.Program {
+	return &entity.Program{Work: entity.Work{ID: workID}, Episode: entity.Episode{ID: nextEpisodeID}}
+}
+
+func TestAppendContinuing(t *testing.T) {
+	tests := []struct {
+		name      string
+		programs  []*entity.Program
+		watching  []*entity.Program
+		wantWorks []string
+	}{
+		{
+			name:      "earlier seasons with an episode left are added",
+			programs:  []*entity.Program{libraryEntry("current", "e1")},
+			watching:  []*entity.Program{libraryEntry("last-season", "e2"), libraryEntry("two-years-ago", "e3")},
+			wantWorks: []string{"current", "last-season", "two-years-ago"},
+		},
+		{
+			name:      "entries without a next episode are left out",
+			programs:  []*entity.Program{libraryEntry("current", "")},
+			watching:  []*entity.Program{libraryEntry("finished", "")},
+			wantWorks: []string{"current"},
+		},
+		{
+			name:      "current season entries are not listed twice",
+			programs:  []*entity.Program{libraryEntry("current", "e1")},
+			watching:  []*entity.Program{libraryEntry("current", "e1")},
+			wantWorks: []string{"current"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, added := appendContinuing(tt.programs, tt.watching)
+			if added != len(tt.wantWorks)-len(tt.programs) {
+				t.Errorf("added = %d, want %d", added, len(tt.wantWorks)-len(tt.programs))
+			}
+			if len(got) != len(tt.wantWorks) {
+				t.Fatalf("got %d entries, want %v", len(got), tt.wantWorks)
+			}
+			for i, p := range got {
+				if p.Work.ID != tt.wantWorks[i] {
+					t.Errorf("entry %d = %s, want %s", i, p.Work.ID, tt.wantWorks[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+	"github.com/monchh/annict-slack-bot/usecase"
+)
+
+const (
+	// revalidateTimeout bounds a background refresh of a stale entry.
+	revalidateTimeout = 30 * time.Second
+	// cachePruneInterval is how often entries that are too old to serve are deleted.
+	cachePruneInterval = time.Hour
+)
+
+// CacheTTL holds the cache lifetimes of each query.
+type CacheTTL struct {
+	Programs time.Duration // FetchTodayPrograms
+	Library  time.Duration // FetchLibraryEntries
+	// Stale is how long an expired entry may still be served while it is refreshed in the background.
+	Stale time.Duration
+}
+
+// ProgramCache caches ProgramRepository results per Annict account and drops them
+// when the account changes records or statuses. One instance is shared by every
+// repository built for a request, so the cache outlives the repositories.
+type ProgramCache struct {
+	backend usecase.CacheRepository
+	ttl     CacheTTL
+	logger  *slog.Logger
+
+	mu          sync.Mutex
+	inflight    map[string]bool      // keys being revalidated
+	invalidated map[string]time.Time // last invalidation per namespace
+	lastPrune   time.Time
+
+	hits, stale, misses atomic.Int64
+}
+
+// NewProgramCache creates a cache on top of the backend.
+func NewProgramCache(backend usecase.CacheRepository, ttl CacheTTL, logger *slog.Logger) *ProgramCache {
+	return &ProgramCache{
+		backend:     backend,
+		ttl:         ttl,
+		logger:      logger,
+		inflight:    make(map[string]bool),
+		invalidated: make(map[string]time.Time),
+	}
+}
+
+// CacheNamespace derives the cache namespace of an Annict account from its access token
+// without keeping the token itself in cache keys.
+func CacheNamespace(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:8])
+}
+
+// Programs wraps the repository so that its results are cached under the namespace.
+func (c *ProgramCache) Programs(namespace string, next usecase.ProgramRepository) usecase.ProgramRepository {
+	return &cachedProgramRepository{cache: c, namespace: namespace, next: next}
+}
+
+// Records wraps the repository so that recording an episode invalidates the namespace.
+func (c *ProgramCache) Records(namespace string, next usecase.RecordRepository) usecase.RecordRepository {
+	return &invalidatingRecordRepository{cache: c, namespace: namespace, next: next}
+}
+
+// Statuses wraps the repository so that changing a status invalidates the namespace.
+func (c *ProgramCache) Statuses(namespace string, next usecase.StatusRepository) usecase.StatusRepository {
+	return &invalidatingStatusRepository{cache: c, namespace: namespace, next: next}
+}
+
+// Invalidate drops every cached result of the namespace.
+func (c *ProgramCache) Invalidate(ctx context.Context, namespace string) {
+	c.mu.Lock()
+	c.invalidated[namespace] = time.Now()
+	c.mu.Unlock()
+
+	if err := c.backend.DeleteCacheByPrefix(ctx, namespace+":"); err != nil {
+		c.logger.WarnContext(ctx, "Failed to invalidate cache", slog.String("namespace", namespace), slog.String("error", err.Error()))
+		return
+	}
+	c.logger.DebugContext(ctx, "Invalidated cache", slog.String("namespace", namespace))
+}
+
+type loadFunc func(ctx context.Context) ([]*entity.Program, error)
+
+// fetch serves the key from cache, refreshing it in the background once expired
+// and loading it synchronously when missing or too old.
+func (c *ProgramCache) fetch(ctx context.Context, namespace, query, key string, ttl time.Duration, load loadFunc) ([]*entity.Program, error) {
+	now := time.Now()
+	cached, found := c.lookup(ctx, key)
+	switch {
+	case found && now.Before(cached.expiresAt):
+		c.hits.Add(1)
+		c.record(ctx, query, "hit")
+		return cached.programs, nil
+	case found && now.Before(cached.expiresAt.Add(c.ttl.Stale)):
+		c.stale.Add(1)
+		c.record(ctx, query, "stale")
+		c.revalidate(namespace, key, ttl, load)
+		return cached.programs, nil
+	}
+
+	c.misses.Add(1)
+	c.record(ctx, query, "miss")
+	startedAt := time.Now()
+	programs, err := load(ctx)
+	if err != nil {
+		if found {
+			c.logger.WarnContext(ctx, "Serving expired cache after fetch failure", slog.String("query", query), slog.String("error", err.Error()))
+			return cached.programs, nil
+		}
+		return nil, err
+	}
+	c.store(ctx, namespace, key, ttl, startedAt, programs)
+	return programs, nil
+}
+
+type cachedPrograms struct {
+	programs  []*entity.Program
+	expiresAt time.Time
+}
+
+func (c *ProgramCache) lookup(ctx context.Context, key string) (*cachedPrograms, bool) {
+	entry, ok, err := c.backend.GetCache(ctx, key)
+	if err != nil {
+		c.logger.WarnContext(ctx, "Failed to read cache", slog.String("key", key), slog.String("error", err.Error()))
+		return nil, false
+	}
+	if !ok {
+		return nil, false
+	}
+	var programs []*entity.Program
+	if err := json.Unmarshal(entry.Value, &programs); err != nil {
+		c.logger.WarnContext(ctx, "Ignored undecodable cache entry", slog.String("key", key), slog.String("error", err.Error()))
+		return nil, false
+	}
+	return &cachedPrograms{programs: programs, expiresAt: entry.ExpiresAt}, true
+}
+
+// store saves the result unless the namespace was invalidated after the fetch started.
+func (c *ProgramCache) store(ctx context.Context, namespace, key string, ttl time.Duration, startedAt time.Time, programs []*entity.Program) {
+	c.mu.Lock()
+	invalidatedAt := c.invalidated[namespace]
+	prune := time.Since(c.lastPrune) >= cachePruneInterval
+	if prune {
+		c.lastPrune = time.Now()
+	}
+	c.mu.Unlock()
+	if startedAt.Before(invalidatedAt) {
+		return
+	}
+
+	value, err := json.Marshal(programs)
+	if err != nil {
+		c.logger.WarnContext(ctx, "Failed to encode cache entry", slog.String("key", key), slog.String("error", err.Error()))
+		return
+	}
+	now := time.Now()
+	entry := &usecase.CacheEntry{Value: value, StoredAt: now, ExpiresAt: now.Add(ttl)}
+	if err := c.backend.SetCache(ctx, key, entry); err != nil {
+		c.logger.WarnContext(ctx, "Failed to write cache", slog.String("key", key), slog.String("error", err.Error()))
+	}
+	if prune {
+		if err := c.backend.PruneCache(ctx, "", now.Add(-c.ttl.Stale)); err != nil {
+			c.logger.WarnContext(ctx, "Failed to prune cache", slog.String("error", err.Error()))
+		}
+	}
+}
+
+// revalidate refreshes the key in the background, at most once at a time per key.
+func (c *ProgramCache) revalidate(namespace, key string, ttl time.Duration, load loadFunc) {
+	c.mu.Lock()
+	if c.inflight[key] {
+		c.mu.Unlock()
+		return
+	}
+	c.inflight[key] = true
+	c.mu.Unlock()
+
+	go func() {
+		defer func() {
+			c.mu.Lock()
+			delete(c.inflight, key)
+			c.mu.Unlock()
+		}()
+		// The request that triggered the refresh may already be done, so it gets its own context.
+		ctx, cancel := context.WithTimeout(context.Background(), revalidateTimeout)
+		defer cancel()
+
+		startedAt := time.Now()
+		programs, err := load(ctx)
+		if err != nil {
+			c.logger.WarnContext(ctx, "Failed to revalidate cache", slog.String("key", key), slog.String("error", err.Error()))
+			return
+		}
+		c.store(ctx, namespace, key, ttl, startedAt, programs)
+	}()
+}
+
+// record logs the lookup result together with the running totals.
+func (c *ProgramCache) record(ctx context.Context, query, result string) {
+	c.logger.InfoContext(ctx, "Annict cache lookup",
+		slog.String("query", query),
+		slog.String("result", result),
+		slog.Int64("hits", c.hits.Load()),
+		slog.Int64("stale", c.stale.Load()),
+		slog.Int64("misses", c.misses.Load()),
+	)
+}
+
+// cachedProgramRepository is a ProgramRepository decorator backed by a ProgramCache.
+type cachedProgramRepository struct {
+	cache     *ProgramCache
+	namespace string
+	next      usecase.ProgramRepository
+}
+
+func (r *cachedProgramRepository) FetchTodayPrograms(ctx context.Context) ([]*entity.Program, error) {
+	// The broadcast day is part of the key, so the cache rolls over with "today".
+	key := fmt.Sprintf("%s:programs:%s", r.namespace, jst.FormatDate(jst.Today()))
+	return r.cache.fetch(ctx, r.namespace, "FetchTodayPrograms", key, r.cache.ttl.Programs, r.next.FetchTodayPrograms)
+}
+
+func (r *cachedProgramRepository) FetchLibraryEntries(ctx context.Context) ([]*entity.Program, error) {
+	key := fmt.Sprintf("%s:library:%s", r.namespace, jst.GetAnnictSeason(jst.Now()))
+	return r.cache.fetch(ctx, r.namespace, "FetchLibraryEntries", key, r.cache.ttl.Library, r.next.FetchLibraryEntries)
+}
+
+// invalidatingRecordRepository drops the account's cache after recording an episode.
+type invalidatingRecordRepository struct {
+	cache     *ProgramCache
+	namespace string
+	next      usecase.RecordRepository
+}
+
+func (r *invalidatingRecordRepository) CreateRecord(ctx context.Context, episodeID string) error {
+	if err := r.next.CreateRecord(ctx, episodeID); err != nil {
+		return err
+	}
+	r.cache.Invalidate(ctx, r.namespace)
+	return nil
+}
+
+// invalidatingStatusRepository drops the account's cache after changing a status.
+type invalidatingStatusRepository struct {
+	cache     *ProgramCache
+	namespace string
+	next      usecase.StatusRepository
+}
+
+func (r *invalidatingStatusRepository) FetchLibraryWorks(ctx context.Context) ([]*entity.Work, error) {
+	return r.next.FetchLibraryWorks(ctx)
+}
+
+func (r *invalidatingStatusRepository) UpdateStatus(ctx context.Context, workID string, status entity.WatchStatus) (*entity.Work, error) {
+	work, err := r.next.UpdateStatus(ctx, workID, status)
+	if err != nil {
+		return nil, err
+	}
+	r.cache.Invalidate(ctx, r.namespace)
+	return work, nil
+}
@@ -0,0 +1,304 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/usecase"
+)
+
+// fakeCacheBackend is an in-memory usecase.CacheRepository.
+type fakeCacheBackend struct {
+	mu      sync.Mutex
+	entries map[string]*usecase.CacheEntry
+}
+
+func newFakeCacheBackend() *fakeCacheBackend {
+	return &fakeCacheBackend{entries: make(map[string]*usecase.CacheEntry)}
+}
+
+func (b *fakeCacheBackend) GetCache(ctx context.Context, key string) (*usecase.CacheEntry, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	entry, ok := b.entries[key]
+	return entry, ok, nil
+}
+
+func (b *fakeCacheBackend) SetCache(ctx context.Context, key string, entry *usecase.CacheEntry) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.entries[key] = entry
+	return nil
+}
+
+func (b *fakeCacheBackend) DeleteCache(ctx context.Context, key string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.entries, key)
+	return nil
+}
+
+func (b *fakeCacheBackend) DeleteCacheByPrefix(ctx context.Context, prefix string) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for key := range b.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(b.entries, key)
+		}
+	}
+	return nil
+}
+
+func (b *fakeCacheBackend) PruneCache(ctx context.Context, prefix string, before time.Time) error {
+	return nil
+}
+
+// titles returns the titles stored under the key, or nil when it is not cached.
+func (b *fakeCacheBackend) titles(t *testing.T, key string) []string {
+	t.Helper()
+	b.mu.Lock()
+	entry, ok := b.entries[key]
+	b.mu.Unlock()
+	if !ok {
+		return nil
+	}
+	var programs []*entity.Program
+	if err := json.Unmarshal(entry.Value, &programs); err != nil {
+		t.Fatalf("cache entry %s is not decodable: %v", key, err)
+	}
+	return programTitles(programs)
+}
+
+func programTitles(programs []*entity.Program) []string {
+	titles := make([]string, len(programs))
+	for i, p := range programs {
+		titles[i] = p.Work.Title
+	}
+	return titles
+}
+
+func programsTitled(titles ...string) []*entity.Program {
+	programs := make([]*entity.Program, len(titles))
+	for i, title := range titles {
+		programs[i] = &entity.Program{Work: entity.Work{Title: title}}
+	}
+	return programs
+}
+
+func newTestProgramCache(backend usecase.CacheRepository) *ProgramCache {
+	ttl := CacheTTL{Programs: time.Minute, Library: time.Minute, Stale: time.Hour}
+	return NewProgramCache(backend, ttl, slog.New(slog.NewTextHandler(io.Discard, nil)))
+}
+
+// seed stores programs under the key that expired the given duration ago (negative for the future).
+func seed(t *testing.T, backend *fakeCacheBackend, key string, expiredAgo time.Duration, titles ...string) {
+	t.Helper()
+	value, err := json.Marshal(programsTitled(titles...))
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Now()
+	backend.entries[key] = &usecase.CacheEntry{Value: value, StoredAt: now.Add(-2 * time.Hour), ExpiresAt: now.Add(-expiredAgo)}
+}
+
+func TestProgramCacheFetch(t *testing.T) {
+	const key = "ns:programs:2026-10-16"
+	loadErr := errors.New("annict is down")
+	tests := []struct {
+		name       string
+		cached     []string      // nil when the key is not cached
+		expiredAgo time.Duration // How long ago the cached entry expired
+		loadErr    error
+		wantTitles []string
+		wantErr    bool
+		wantLoads  int // Synchronous loads
+		wantStored []string
+	}{
+		{
+			name:       "miss loads and stores",
+			wantTitles: []string{"fresh"},
+			wantLoads:  1,
+			wantStored: []string{"fresh"},
+		},
+		{
+			name:       "fresh entry is served without loading",
+			cached:     []string{"cached"},
+			expiredAgo: -time.Minute,
+			wantTitles: []string{"cached"},
+			wantStored: []string{"cached"},
+		},
+		{
+			name:       "entry past the stale window is loaded again",
+			cached:     []string{"cached"},
+			expiredAgo: 2 * time.Hour,
+			wantTitles: []string{"fresh"},
+			wantLoads:  1,
+			wantStored: []string{"fresh"},
+		},
+		{
+			name:       "expired entry is served when loading fails",
+			cached:     []string{"cached"},
+			expiredAgo: 2 * time.Hour,
+			loadErr:    loadErr,
+			wantTitles: []string{"cached"},
+			wantLoads:  1,
+			wantStored: []string{"cached"},
+		},
+		{
+			name:      "miss returns the load error",
+			loadErr:   loadErr,
+			wantErr:   true,
+			wantLoads: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			backend := newFakeCacheBackend()
+			if tt.cached != nil {
+				seed(t, backend, key, tt.expiredAgo, tt.cached...)
+			}
+			c := newTestProgramCache(backend)
+			loads := 0
+			load := func(ctx context.Context) ([]*entity.Program, error) {
+				loads++
+				if tt.loadErr != nil {
+					return nil, tt.loadErr
+				}
+				return programsTitled("fresh"), nil
+			}
+
+			programs, err := c.fetch(context.Background(), "ns", "FetchTodayPrograms", key, time.Minute, load)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatal("fetch returned no error")
+				}
+			} else if err != nil {
+				t.Fatalf("fetch returned error: %v", err)
+			}
+			if got := programTitles(programs); !equalStrings(got, tt.wantTitles) {
+				t.Errorf("titles = %v, want %v", got, tt.wantTitles)
+			}
+			if loads != tt.wantLoads {
+				t.Errorf("loads = %d, want %d", loads, tt.wantLoads)
+			}
+			if got := backend.titles(t, key); !equalStrings(got, tt.wantStored) {
+				t.Errorf("stored = %v, want %v", got, tt.wantStored)
+			}
+		})
+	}
+}
+
+func TestProgramCacheRevalidatesStaleEntry(t *testing.T) {
+	const key = "ns:programs:2026-10-16"
+	backend := newFakeCacheBackend()
+	seed(t, backend, key, time.Minute, "stale")
+	c := newTestProgramCache(backend)
+
+	refreshed := make(chan struct{})
+	load := func(ctx context.Context) ([]*entity.Program, error) {
+		defer close(refreshed)
+		return programsTitled("fresh"), nil
+	}
+	programs, err := c.fetch(context.Background(), "ns", "FetchTodayPrograms", key, time.Minute, load)
+	if err != nil {
+		t.Fatalf("fetch returned error: %v", err)
+	}
+	if got := programTitles(programs); !equalStrings(got, []string{"stale"}) {
+		t.Errorf("titles = %v, want the stale entry", got)
+	}
+
+	select {
+	case <-refreshed:
+	case <-time.After(time.Second):
+		t.Fatal("stale entry was not revalidated in the background")
+	}
+	deadline := time.Now().Add(time.Second)
+	for !equalStrings(backend.titles(t, key), []string{"fresh"}) {
+		if time.Now().After(deadline) {
+			t.Fatalf("stored = %v after revalidation, want [fresh]", backend.titles(t, key))
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestProgramCacheInvalidation(t *testing.T) {
+	const key = "ns:programs:2026-10-16"
+	backend := newFakeCacheBackend()
+	seed(t, backend, key, -time.Minute, "cached")
+	seed(t, backend, "other:programs:2026-10-16", -time.Minute, "other")
+	c := newTestProgramCache(backend)
+
+	records := c.Records("ns", recordRepositoryFunc(func(ctx context.Context, episodeID string) error { return nil }))
+	if err := records.CreateRecord(context.Background(), "episode"); err != nil {
+		t.Fatalf("CreateRecord returned error: %v", err)
+	}
+	if got := backend.titles(t, key); got != nil {
+		t.Errorf("stored = %v after recording, want the namespace dropped", got)
+	}
+	if got := backend.titles(t, "other:programs:2026-10-16"); !equalStrings(got, []string{"other"}) {
+		t.Errorf("other namespace = %v, want it kept", got)
+	}
+
+	// A load that started before the invalidation must not store its now outdated result.
+	load := func(ctx context.Context) ([]*entity.Program, error) {
+		c.Invalidate(ctx, "ns")
+		return programsTitled("outdated"), nil
+	}
+	if _, err := c.fetch(context.Background(), "ns", "FetchTodayPrograms", key, time.Minute, load); err != nil {
+		t.Fatalf("fetch returned error: %v", err)
+	}
+	if got := backend.titles(t, key); got != nil {
+		t.Errorf("stored = %v, want the result loaded across an invalidation dropped", got)
+	}
+}
+
+func TestProgramCacheKeepsNamespaceOnFailedRecord(t *testing.T) {
+	const key = "ns:programs:2026-10-16"
+	backend := newFakeCacheBackend()
+	seed(t, backend, key, -time.Minute, "cached")
+	c := newTestProgramCache(backend)
+
+	records := c.Records("ns", recordRepositoryFunc(func(ctx context.Context, episodeID string) error {
+		return errors.New("rejected")
+	}))
+	if err := records.CreateRecord(context.Background(), "episode"); err == nil {
+		t.Fatal("CreateRecord returned no error")
+	}
+	if got := backend.titles(t, key); !equalStrings(got, []string{"cached"}) {
+		t.Errorf("stored = %v after a failed record, want it kept", got)
+	}
+}
+
+func TestCacheNamespaceHidesToken(t *testing.T) {
+	ns := CacheNamespace("secret-token")
+	if strings.Contains(ns, "secret") || ns != CacheNamespace("secret-token") || ns == CacheNamespace("other-token") {
+		t.Errorf("CacheNamespace = %q, want a stable digest that does not contain the token", ns)
+	}
+}
+
+// recordRepositoryFunc adapts a function to usecase.RecordRepository.
+type recordRepositoryFunc func(ctx context.Context, episodeID string) error
+
+func (f recordRepositoryFunc) CreateRecord(ctx context.Context, episodeID string) error {
+	return f(ctx, episodeID)
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,119 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/usecase"
+)
+
+// cachePruneThreshold is the number of entries above which expired results are swept.
+const cachePruneThreshold = 1024
+
+// validationResult is a cached outcome of validating one URL.
+type validationResult struct {
+	isValid   bool
+	expiresAt time.Time
+}
+
+// inflightValidation lets concurrent callers of the same URL share one request.
+type inflightValidation struct {
+	done    chan struct{}
+	isValid bool
+}
+
+// cachedImageValidator remembers validation results per URL so that the same image
+// (in particular a broken one) is not requested again on every call.
+type cachedImageValidator struct {
+	next       usecase.ImageValidationService
+	validTTL   time.Duration
+	invalidTTL time.Duration
+	now        func() time.Time
+
+	mu       sync.Mutex
+	results  map[string]validationResult
+	inflight map[string]*inflightValidation
+}
+
+// NewCachedImageValidator wraps the validator with a per-URL cache.
+// Valid and invalid results are kept for validTTL and invalidTTL respectively;
+// a non-positive TTL disables caching of that result.
+func NewCachedImageValidator(next usecase.ImageValidationService, validTTL, invalidTTL time.Duration) usecase.ImageValidationService {
+	return &cachedImageValidator{
+		next:       next,
+		validTTL:   validTTL,
+		invalidTTL: invalidTTL,
+		now:        time.Now,
+		results:    make(map[string]validationResult),
+		inflight:   make(map[string]*inflightValidation),
+	}
+}
+
+// ValidateURL serves the cached result of the URL, validating it only when missing or expired.
+func (v *cachedImageValidator) ValidateURL(ctx context.Context, url string) (isValid bool, validatedURL string) {
+	if url == "" {
+		return false, ""
+	}
+
+	v.mu.Lock()
+	if result, ok := v.results[url]; ok && v.now().Before(result.expiresAt) {
+		v.mu.Unlock()
+		slog.Debug(fmt.Sprintf("Image validation cache hit for %s (valid: %t)", url, result.isValid))
+		return validated(url, result.isValid)
+	}
+	if call, ok := v.inflight[url]; ok {
+		v.mu.Unlock()
+		select {
+		case <-call.done:
+			return validated(url, call.isValid)
+		case <-ctx.Done():
+			return false, ""
+		}
+	}
+	call := &inflightValidation{done: make(chan struct{})}
+	v.inflight[url] = call
+	v.mu.Unlock()
+
+	call.isValid, _ = v.next.ValidateURL(ctx, url)
+
+	v.mu.Lock()
+	delete(v.inflight, url)
+	// A failure caused by the caller giving up says nothing about the image, so it is not cached.
+	if ctx.Err() == nil {
+		v.store(url, call.isValid)
+	}
+	v.mu.Unlock()
+	close(call.done)
+
+	return validated(url, call.isValid)
+}
+
+// store records the result. The caller must hold the lock.
+func (v *cachedImageValidator) store(url string, isValid bool) {
+	ttl := v.invalidTTL
+	if isValid {
+		ttl = v.validTTL
+	}
+	if ttl <= 0 {
+		return
+	}
+	now := v.now()
+	if len(v.results) >= cachePruneThreshold {
+		for key, result := range v.results {
+			if !now.Before(result.expiresAt) {
+				delete(v.results, key)
+			}
+		}
+	}
+	v.results[url] = validationResult{isValid: isValid, expiresAt: now.Add(ttl)}
+}
+
+func validated(url string, isValid bool) (bool, string) {
+	if !isValid {
+		return false, ""
+	}
+	return true, url
+}
@@ -0,0 +1,158 @@
+package validator
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// fakeValidator answers from a fixed set of valid URLs and counts the lookups.
+// When release is set, every lookup waits for it to be closed.
+type fakeValidator struct {
+	valid   map[string]bool
+	release chan struct{}
+	started chan struct{} // Receives once per lookup when set
+	calls   atomic.Int32
+}
+
+func (f *fakeValidator) ValidateURL(ctx context.Context, url string) (bool, string) {
+	f.calls.Add(1)
+	if f.started != nil {
+		f.started <- struct{}{}
+	}
+	if f.release != nil {
+		select {
+		case <-f.release:
+		case <-ctx.Done():
+			return false, ""
+		}
+	}
+	if ctx.Err() != nil || !f.valid[url] {
+		return false, ""
+	}
+	return true, url
+}
+
+// fakeClock is a clock the test moves forward by hand.
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func newTestCachedValidator(next *fakeValidator, validTTL, invalidTTL time.Duration) (*cachedImageValidator, *fakeClock) {
+	clock := &fakeClock{now: time.Date(2026, time.October, 16, 12, 0, 0, 0, time.UTC)}
+	v := NewCachedImageValidator(next, validTTL, invalidTTL).(*cachedImageValidator)
+	v.now = clock.Now
+	return v, clock
+}
+
+const (
+	validImage  = "https://example.com/valid.png"
+	brokenImage = "https://example.com/broken.png"
+)
+
+func TestCachedImageValidatorTTL(t *testing.T) {
+	tests := []struct {
+		name       string
+		url        string
+		validTTL   time.Duration
+		invalidTTL time.Duration
+		advance    time.Duration // Time passed before the second lookup
+		wantCalls  int32
+	}{
+		{name: "valid result is cached", url: validImage, validTTL: time.Hour, invalidTTL: time.Minute, advance: 59 * time.Minute, wantCalls: 1},
+		{name: "valid result expires", url: validImage, validTTL: time.Hour, invalidTTL: time.Minute, advance: time.Hour, wantCalls: 2},
+		{name: "invalid result is cached", url: brokenImage, validTTL: time.Hour, invalidTTL: 10 * time.Minute, advance: 9 * time.Minute, wantCalls: 1},
+		{name: "invalid result expires", url: brokenImage, validTTL: time.Hour, invalidTTL: 10 * time.Minute, advance: 10 * time.Minute, wantCalls: 2},
+		{name: "invalid TTL does not apply to valid results", url: validImage, validTTL: time.Hour, invalidTTL: 10 * time.Minute, advance: 30 * time.Minute, wantCalls: 1},
+		{name: "non-positive TTL disables caching", url: brokenImage, validTTL: time.Hour, invalidTTL: 0, wantCalls: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			next := &fakeValidator{valid: map[string]bool{validImage: true}}
+			v, clock := newTestCachedValidator(next, tt.validTTL, tt.invalidTTL)
+			wantValid := tt.url == validImage
+
+			for i := range 2 {
+				isValid, url := v.ValidateURL(ctx, tt.url)
+				if isValid != wantValid || (isValid && url != tt.url) {
+					t.Errorf("lookup %d = %v, %q; want %v", i+1, isValid, url, wantValid)
+				}
+				clock.now = clock.now.Add(tt.advance)
+			}
+			if got := next.calls.Load(); got != tt.wantCalls {
+				t.Errorf("validator was called %d times, want %d", got, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestCachedImageValidatorSharesConcurrentLookups(t *testing.T) {
+	const callers = 10
+	next := &fakeValidator{
+		valid:   map[string]bool{validImage: true},
+		release: make(chan struct{}),
+		started: make(chan struct{}, callers),
+	}
+	v, _ := newTestCachedValidator(next, time.Hour, time.Minute)
+
+	var wg sync.WaitGroup
+	results := make([]bool, callers)
+	for i := range callers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i], _ = v.ValidateURL(context.Background(), validImage)
+		}()
+	}
+	<-next.started
+	// Give the other callers time to find the lookup in flight before it finishes.
+	time.Sleep(20 * time.Millisecond)
+	close(next.release)
+	wg.Wait()
+
+	if got := next.calls.Load(); got != 1 {
+		t.Errorf("validator was called %d times, want 1", got)
+	}
+	for i, isValid := range results {
+		if !isValid {
+			t.Errorf("caller %d got an invalid result", i)
+		}
+	}
+}
+
+func TestCachedImageValidatorSkipsCancelledLookups(t *testing.T) {
+	tests := []struct {
+		name string
+		url  string
+	}{
+		{name: "valid image", url: validImage},
+		{name: "broken image", url: brokenImage},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			next := &fakeValidator{valid: map[string]bool{validImage: true}}
+			v, _ := newTestCachedValidator(next, time.Hour, time.Hour)
+
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+			if isValid, _ := v.ValidateURL(ctx, tt.url); isValid {
+				t.Error("cancelled lookup returned a valid result")
+			}
+
+			wantValid := tt.url == validImage
+			if isValid, _ := v.ValidateURL(context.Background(), tt.url); isValid != wantValid {
+				t.Errorf("lookup after the cancelled one = %v, want %v", isValid, wantValid)
+			}
+			if got := next.calls.Load(); got != 2 {
+				t.Errorf("validator was called %d times, want 2 (the cancelled result must not be cached)", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,140 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/usecase"
+)
+
+const (
+	ogPageTimeout = 10 * time.Second
+	// ogPageReadLimit bounds how much of a page is read; <meta> tags live in the <head>.
+	ogPageReadLimit = 512 << 10
+)
+
+var (
+	metaTagPattern   = regexp.MustCompile(`(?is)<meta\s[^>]*>`)
+	attributePattern = regexp.MustCompile(`(?is)([a-z:-]+)\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+// ogResult is a cached og:image lookup. An empty imageURL means the page has none.
+type ogResult struct {
+	imageURL  string
+	expiresAt time.Time
+}
+
+// httpOGImageFetcher implements the OGImageFetcher by reading the page's <meta> tags.
+type httpOGImageFetcher struct {
+	httpClient HTTPClient // Must follow redirects: official sites often move
+	ttl        time.Duration
+
+	mu      sync.Mutex
+	results map[string]ogResult
+}
+
+// NewHTTPOGImageFetcher creates a fetcher that remembers each page's og:image for ttl.
+func NewHTTPOGImageFetcher(client HTTPClient, ttl time.Duration) usecase.OGImageFetcher {
+	return &httpOGImageFetcher{
+		httpClient: client,
+		ttl:        ttl,
+		results:    make(map[string]ogResult),
+	}
+}
+
+// FetchOGImage returns the absolute og:image URL of the page, or "" when it has none.
+func (f *httpOGImageFetcher) FetchOGImage(ctx context.Context, pageURL string) (string, error) {
+	f.mu.Lock()
+	if result, ok := f.results[pageURL]; ok && time.Now().Before(result.expiresAt) {
+		f.mu.Unlock()
+		return result.imageURL, nil
+	}
+	f.mu.Unlock()
+
+	imageURL, err := f.fetch(ctx, pageURL)
+	if err != nil {
+		if ctx.Err() != nil {
+			return "", err
+		}
+		// Unreachable sites are remembered like sites without og:image.
+		imageURL = ""
+	}
+
+	f.mu.Lock()
+	now := time.Now()
+	if len(f.results) >= cachePruneThreshold {
+		for key, result := range f.results {
+			if !now.Before(result.expiresAt) {
+				delete(f.results, key)
+			}
+		}
+	}
+	f.results[pageURL] = ogResult{imageURL: imageURL, expiresAt: now.Add(f.ttl)}
+	f.mu.Unlock()
+	return imageURL, err
+}
+
+func (f *httpOGImageFetcher) fetch(ctx context.Context, pageURL string) (string, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, ogPageTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/html")
+	resp, err := f.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, ogPageReadLimit))
+	if err != nil {
+		return "", fmt.Errorf("failed to read page: %w", err)
+	}
+	content := findOGImage(string(body))
+	if content == "" {
+		return "", nil
+	}
+	// og:image may be relative; resolve it against the final (post-redirect) page URL.
+	base := resp.Request.URL
+	ref, err := url.Parse(content)
+	if err != nil {
+		return "", fmt.Errorf("invalid og:image %q: %w", content, err)
+	}
+	resolved := base.ResolveReference(ref)
+	if resolved.Scheme != "http" && resolved.Scheme != "https" {
+		return "", nil
+	}
+	return resolved.String(), nil
+}
+
+// findOGImage returns the content of the first og:image (or og:image:url) meta tag.
+func findOGImage(page string) string {
+	for _, tag := range metaTagPattern.FindAllString(page, -1) {
+		attrs := make(map[string]string)
+		for _, m := range attributePattern.FindAllStringSubmatch(tag, -1) {
+			attrs[strings.ToLower(m[1])] = m[2] + m[3]
+		}
+		key := strings.ToLower(attrs["property"])
+		if key == "" {
+			key = strings.ToLower(attrs["name"])
+		}
+		if (key == "og:image" || key == "og:image:url") && strings.TrimSpace(attrs["content"]) != "" {
+			return html.UnescapeString(strings.TrimSpace(attrs["content"]))
+		}
+	}
+	return ""
+}
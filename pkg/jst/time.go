@@ -3,11 +3,18 @@ package jst
 import (
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 )
 
 var jstLocation *time.Location
 
+// Broadcast day settings (see SetDayRolloverHour and SetThirtyHourNotation).
+var (
+	dayRolloverHour    int
+	thirtyHourNotation bool
+)
+
 const (
 	DateLayout = "2006-01-02"
 	TimeLayout = "15:04"
@@ -27,7 +34,18 @@ func Location() *time.Location                 { return jstLocation }
 func Now() time.Time                           { return time.Now().In(jstLocation) }
 func Format(t time.Time, layout string) string { return t.In(jstLocation).Format(layout) }
 func FormatDate(t time.Time) string            { return Format(t, DateLayout) }
-func FormatTime(t time.Time) string            { return Format(t, TimeLayout) }
+func FormatTime(t time.Time) string {
+	if thirtyHourNotation {
+		return FormatThirtyHourTime(t)
+	}
+	return Format(t, TimeLayout)
+}
+func FormatDateTime(t time.Time) string {
+	if thirtyHourNotation {
+		return FormatDate(BroadcastDay(t)) + " " + FormatThirtyHourTime(t)
+	}
+	return FormatDate(t) + " " + Format(t, TimeLayout)
+}
 func ParseInJST(layout, value string) (time.Time, error) {
 	return time.ParseInLocation(layout, value, jstLocation)
 }
@@ -55,6 +73,84 @@ func GetAnnictSeason(t time.Time) string {
 	}
 	return fmt.Sprintf("%d-%s", year, season)
 }
+
+// NextAnnictSeason returns the Annict season following the one containing t.
+func NextAnnictSeason(t time.Time) string {
+	return GetAnnictSeason(NextAnnictSeasonStart(t))
+}
+
+// NextAnnictSeasonStart returns the first day (00:00 JST) of the season following the one containing t.
+func NextAnnictSeasonStart(t time.Time) time.Time {
+	jstTime := t.In(jstLocation)
+	// Seasons start in January, April, July and October; month 13 normalizes to next January.
+	month := (int(jstTime.Month())-1)/3*3 + 4
+	return time.Date(jstTime.Year(), time.Month(month), 1, 0, 0, 0, 0, jstLocation)
+}
+
+// seasonAliases maps the accepted season spellings to Annict's season names.
+var seasonAliases = map[string]string{
+	"winter": "winter", "冬": "winter",
+	"spring": "spring", "春": "spring",
+	"summer": "summer", "夏": "summer",
+	"autumn": "autumn", "fall": "autumn", "秋": "autumn",
+}
+
+// ParseAnnictSeason normalizes a season such as "2026-autumn", "2026-fall" or "2026秋"
+// to Annict's "2026-autumn" form. It reports false when the input is not a season.
+func ParseAnnictSeason(s string) (string, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	digits := 0
+	for digits < len(s) && s[digits] >= '0' && s[digits] <= '9' {
+		digits++
+	}
+	if digits != 4 {
+		return "", false
+	}
+	year, name := s[:digits], strings.TrimPrefix(strings.TrimPrefix(s[digits:], "-"), "年")
+	season, ok := seasonAliases[name]
+	if !ok {
+		return "", false
+	}
+	return year + "-" + season, true
+}
+func StartOfDay(t time.Time) time.Time {
+	jstTime := t.In(jstLocation)
+	return time.Date(jstTime.Year(), jstTime.Month(), jstTime.Day(), 0, 0, 0, 0, jstLocation)
+}
 func IsSameDate(t1, t2 time.Time) bool {
-	return t1.In(jstLocation).Truncate(24 * time.Hour).Equal(t2.In(jstLocation).Truncate(24 * time.Hour))
+	return StartOfDay(t1).Equal(StartOfDay(t2))
+}
+
+// SetDayRolloverHour sets the hour (JST) at which a broadcast day starts, e.g. 5 for 05:00.
+// Late-night programs before this hour belong to the previous broadcast day.
+func SetDayRolloverHour(hour int) error {
+	if hour < 0 || hour > 23 {
+		return fmt.Errorf("day rollover hour must be between 0 and 23: %d", hour)
+	}
+	dayRolloverHour = hour
+	return nil
+}
+func DayRolloverHour() int { return dayRolloverHour }
+
+// SetThirtyHourNotation enables rendering times before the rollover hour as 24:00-29:59,
+// the way Japanese TV listings do.
+func SetThirtyHourNotation(enabled bool) { thirtyHourNotation = enabled }
+
+// BroadcastDay returns the start (00:00 JST) of the broadcast day that t belongs to.
+func BroadcastDay(t time.Time) time.Time {
+	return StartOfDay(t.In(jstLocation).Add(-time.Duration(dayRolloverHour) * time.Hour))
+}
+func Today() time.Time { return BroadcastDay(Now()) }
+func IsSameBroadcastDay(t1, t2 time.Time) bool {
+	return BroadcastDay(t1).Equal(BroadcastDay(t2))
+}
+
+// FormatThirtyHourTime formats t relative to its broadcast day, e.g. "25:30" for 01:30.
+func FormatThirtyHourTime(t time.Time) string {
+	jstTime := t.In(jstLocation)
+	hour := jstTime.Hour()
+	if hour < dayRolloverHour {
+		hour += 24
+	}
+	return fmt.Sprintf("%02d:%02d", hour, jstTime.Minute())
 }
@@ -0,0 +1,118 @@
+package jst
+
+import (
+	"testing"
+	"time"
+)
+
+// withBroadcastSettings sets the rollover hour and 30-hour notation for one test.
+func withBroadcastSettings(t *testing.T, hour int, thirtyHour bool) {
+	t.Helper()
+	prevHour, prevThirtyHour := dayRolloverHour, thirtyHourNotation
+	if err := SetDayRolloverHour(hour); err != nil {
+		t.Fatalf("SetDayRolloverHour(%d) returned error: %v", hour, err)
+	}
+	SetThirtyHourNotation(thirtyHour)
+	t.Cleanup(func() {
+		dayRolloverHour, thirtyHourNotation = prevHour, prevThirtyHour
+	})
+}
+
+func at(month time.Month, day, hour, minute int) time.Time {
+	return time.Date(2026, month, day, hour, minute, 0, 0, Location())
+}
+
+func TestBroadcastDay(t *testing.T) {
+	tests := []struct {
+		name         string
+		rolloverHour int
+		t            time.Time
+		want         time.Time
+	}{
+		{name: "late night belongs to the previous day", rolloverHour: 5, t: at(time.October, 17, 1, 30), want: at(time.October, 16, 0, 0)},
+		{name: "just before the rollover", rolloverHour: 5, t: at(time.October, 17, 4, 59), want: at(time.October, 16, 0, 0)},
+		{name: "at the rollover", rolloverHour: 5, t: at(time.October, 17, 5, 0), want: at(time.October, 17, 0, 0)},
+		{name: "across a month boundary", rolloverHour: 5, t: at(time.November, 1, 2, 0), want: at(time.October, 31, 0, 0)},
+		{name: "no rollover keeps the calendar date", rolloverHour: 0, t: at(time.October, 17, 1, 30), want: at(time.October, 17, 0, 0)},
+		{name: "UTC input is converted to JST", rolloverHour: 5, t: time.Date(2026, time.October, 16, 16, 30, 0, 0, time.UTC), want: at(time.October, 16, 0, 0)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withBroadcastSettings(t, tt.rolloverHour, true)
+			if got := BroadcastDay(tt.t); !got.Equal(tt.want) {
+				t.Errorf("BroadcastDay(%s) = %s, want %s", tt.t, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsSameBroadcastDay(t *testing.T) {
+	withBroadcastSettings(t, 5, true)
+	if !IsSameBroadcastDay(at(time.October, 16, 23, 0), at(time.October, 17, 2, 0)) {
+		t.Error("23:00 and 02:00 of the next calendar day should share a broadcast day")
+	}
+	if IsSameBroadcastDay(at(time.October, 17, 2, 0), at(time.October, 17, 6, 0)) {
+		t.Error("02:00 and 06:00 should be different broadcast days")
+	}
+}
+
+func TestFormatTime(t *testing.T) {
+	tests := []struct {
+		name         string
+		rolloverHour int
+		thirtyHour   bool
+		t            time.Time
+		wantTime     string
+		wantDateTime string
+	}{
+		{name: "late night in 30-hour notation", rolloverHour: 5, thirtyHour: true, t: at(time.October, 17, 1, 30), wantTime: "25:30", wantDateTime: "2026-10-16 25:30"},
+		{name: "last minute before the rollover", rolloverHour: 5, thirtyHour: true, t: at(time.October, 17, 4, 59), wantTime: "28:59", wantDateTime: "2026-10-16 28:59"},
+		{name: "daytime is unchanged", rolloverHour: 5, thirtyHour: true, t: at(time.October, 17, 5, 0), wantTime: "05:00", wantDateTime: "2026-10-17 05:00"},
+		{name: "30-hour notation disabled", rolloverHour: 5, thirtyHour: false, t: at(time.October, 17, 1, 30), wantTime: "01:30", wantDateTime: "2026-10-17 01:30"},
+		{name: "no rollover", rolloverHour: 0, thirtyHour: true, t: at(time.October, 17, 0, 15), wantTime: "00:15", wantDateTime: "2026-10-17 00:15"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			withBroadcastSettings(t, tt.rolloverHour, tt.thirtyHour)
+			if got := FormatTime(tt.t); got != tt.wantTime {
+				t.Errorf("FormatTime(%s) = %q, want %q", tt.t, got, tt.wantTime)
+			}
+			if got := FormatDateTime(tt.t); got != tt.wantDateTime {
+				t.Errorf("FormatDateTime(%s) = %q, want %q", tt.t, got, tt.wantDateTime)
+			}
+		})
+	}
+}
+
+func TestSetDayRolloverHourRejectsOutOfRange(t *testing.T) {
+	withBroadcastSettings(t, 5, true)
+	for _, hour := range []int{-1, 24} {
+		if err := SetDayRolloverHour(hour); err == nil {
+			t.Errorf("SetDayRolloverHour(%d) accepted an invalid hour", hour)
+		}
+	}
+	if got := DayRolloverHour(); got != 5 {
+		t.Errorf("DayRolloverHour() = %d after invalid input, want 5", got)
+	}
+}
+
+func TestAnnictSeasons(t *testing.T) {
+	tests := []struct {
+		t           time.Time
+		wantCurrent string
+		wantNext    string
+	}{
+		{t: at(time.October, 16, 12, 0), wantCurrent: "2026-autumn", wantNext: "2027-winter"},
+		{t: at(time.January, 1, 0, 0), wantCurrent: "2026-winter", wantNext: "2026-spring"},
+		{t: at(time.March, 31, 23, 59), wantCurrent: "2026-winter", wantNext: "2026-spring"},
+		{t: at(time.April, 1, 0, 0), wantCurrent: "2026-spring", wantNext: "2026-summer"},
+	}
+	for _, tt := range tests {
+		if got := GetAnnictSeason(tt.t); got != tt.wantCurrent {
+			t.Errorf("GetAnnictSeason(%s) = %q, want %q", tt.t, got, tt.wantCurrent)
+		}
+		if got := NextAnnictSeason(tt.t); got != tt.wantNext {
+			t.Errorf("NextAnnictSeason(%s) = %q, want %q", tt.t, got, tt.wantNext)
+		}
+	}
+}
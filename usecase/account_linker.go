@@ -0,0 +1,194 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// linkStateTTL is how long an OAuth authorize URL stays valid.
+const linkStateTTL = 10 * time.Minute
+
+var (
+	// ErrAccountNotLinked is returned when the Slack user has not linked an Annict account.
+	ErrAccountNotLinked = errors.New("annict account is not linked")
+	// ErrLinkingDisabled is returned when accounts are linked without a token store,
+	// i.e. no ANNICT_TOKEN_ENCRYPTION_KEY is configured.
+	ErrLinkingDisabled = errors.New("annict account linking is disabled")
+	// ErrOAuthNotConfigured is returned when the OAuth flow is used without client credentials.
+	ErrOAuthNotConfigured = errors.New("annict oauth is not configured")
+	// ErrInvalidLinkState is returned when an OAuth callback carries an unknown, used or expired state.
+	ErrInvalidLinkState = errors.New("invalid or expired oauth state")
+)
+
+// TokenStore defines the interface for keeping Annict access tokens per Slack user.
+// The implementation will reside in the infrastructure layer.
+type TokenStore interface {
+	Token(ctx context.Context, slackUserID string) (string, bool, error)
+	SaveToken(ctx context.Context, slackUserID, token string) error
+	DeleteToken(ctx context.Context, slackUserID string) error
+}
+
+// AccountVerifier defines the interface for resolving the Annict account of an access token.
+// The implementation will reside in the interfaces layer.
+type AccountVerifier interface {
+	FetchViewer(ctx context.Context, token string) (*entity.Viewer, error)
+}
+
+// OAuthProvider defines the interface for Annict's OAuth2 authorization-code flow.
+// The implementation will reside in the infrastructure layer.
+type OAuthProvider interface {
+	// AuthorizeURL returns the URL the user opens to grant access.
+	AuthorizeURL(state string) string
+	// Exchange trades an authorization code for an access token.
+	Exchange(ctx context.Context, code string) (string, error)
+	// Revoke invalidates an access token on Annict.
+	Revoke(ctx context.Context, token string) error
+}
+
+// LinkStateStore defines the interface for pending OAuth states.
+// A state is bound to the Slack user who requested it and can be consumed only once.
+type LinkStateStore interface {
+	Save(state, slackUserID string, expiresAt time.Time) error
+	Consume(state string, now time.Time) (string, bool, error)
+}
+
+// AccountLinker defines the use case for linking Slack users to their own Annict accounts.
+type AccountLinker struct {
+	store    TokenStore
+	verifier AccountVerifier
+	oauth    OAuthProvider
+	states   LinkStateStore
+}
+
+// NewAccountLinker creates a new instance of the use case.
+// oauth and states may be nil, in which case only personal access tokens can be linked.
+// store may be nil, in which case linking is disabled and no user has a linked account.
+func NewAccountLinker(store TokenStore, verifier AccountVerifier, oauth OAuthProvider, states LinkStateStore) *AccountLinker {
+	return &AccountLinker{
+		store:    store,
+		verifier: verifier,
+		oauth:    oauth,
+		states:   states,
+	}
+}
+
+// LinkingEnabled reports whether Slack users can link their own Annict accounts.
+func (al *AccountLinker) LinkingEnabled() bool {
+	return al.store != nil
+}
+
+// OAuthEnabled reports whether accounts can be linked with the OAuth flow.
+func (al *AccountLinker) OAuthEnabled() bool {
+	return al.LinkingEnabled() && al.oauth != nil && al.states != nil
+}
+
+// StartLink issues a single-use state for the Slack user and returns the authorize URL.
+func (al *AccountLinker) StartLink(ctx context.Context, slackUserID string) (string, error) {
+	if !al.OAuthEnabled() {
+		return "", ErrOAuthNotConfigured
+	}
+	if slackUserID == "" {
+		return "", fmt.Errorf("slack user ID is required")
+	}
+	state, err := newLinkState()
+	if err != nil {
+		return "", err
+	}
+	if err := al.states.Save(state, slackUserID, time.Now().Add(linkStateTTL)); err != nil {
+		return "", fmt.Errorf("failed to save oauth state for %s: %w", slackUserID, err)
+	}
+	return al.oauth.AuthorizeURL(state), nil
+}
+
+// CompleteLink consumes the state of an OAuth callback, exchanges the code and links the token
+// to the Slack user who started the flow.
+func (al *AccountLinker) CompleteLink(ctx context.Context, state, code string) (string, *entity.Viewer, error) {
+	if !al.OAuthEnabled() {
+		return "", nil, ErrOAuthNotConfigured
+	}
+	slackUserID, ok, err := al.states.Consume(state, time.Now())
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to read oauth state: %w", err)
+	}
+	if !ok {
+		return "", nil, ErrInvalidLinkState
+	}
+	token, err := al.oauth.Exchange(ctx, code)
+	if err != nil {
+		return slackUserID, nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+	viewer, err := al.Link(ctx, slackUserID, token)
+	if err != nil {
+		return slackUserID, nil, err
+	}
+	return slackUserID, viewer, nil
+}
+
+// Link verifies the token against Annict and stores it for the Slack user.
+func (al *AccountLinker) Link(ctx context.Context, slackUserID, token string) (*entity.Viewer, error) {
+	if !al.LinkingEnabled() {
+		return nil, ErrLinkingDisabled
+	}
+	token = strings.TrimSpace(token)
+	if slackUserID == "" || token == "" {
+		return nil, fmt.Errorf("slack user ID and token are required")
+	}
+	viewer, err := al.verifier.FetchViewer(ctx, token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify annict token: %w", err)
+	}
+	if err := al.store.SaveToken(ctx, slackUserID, token); err != nil {
+		return nil, fmt.Errorf("failed to save annict token for %s: %w", slackUserID, err)
+	}
+	return viewer, nil
+}
+
+// Unlink revokes the Slack user's token on Annict (when OAuth is configured) and forgets it.
+// A failed revocation is logged but does not keep the account linked.
+func (al *AccountLinker) Unlink(ctx context.Context, slackUserID string) error {
+	token, err := al.Token(ctx, slackUserID)
+	if err != nil {
+		return err
+	}
+	if al.oauth != nil {
+		if err := al.oauth.Revoke(ctx, token); err != nil {
+			slog.Warn(fmt.Sprintf("Failed to revoke annict token for %s: %v", slackUserID, err))
+		}
+	}
+	if err := al.store.DeleteToken(ctx, slackUserID); err != nil {
+		return fmt.Errorf("failed to delete annict token for %s: %w", slackUserID, err)
+	}
+	return nil
+}
+
+// Token returns the access token linked to the Slack user, or ErrAccountNotLinked.
+func (al *AccountLinker) Token(ctx context.Context, slackUserID string) (string, error) {
+	if !al.LinkingEnabled() {
+		return "", ErrAccountNotLinked
+	}
+	token, ok, err := al.store.Token(ctx, slackUserID)
+	if err != nil {
+		return "", fmt.Errorf("failed to read annict token for %s: %w", slackUserID, err)
+	}
+	if !ok || token == "" {
+		return "", ErrAccountNotLinked
+	}
+	return token, nil
+}
+
+// newLinkState returns a random, URL-safe OAuth state.
+func newLinkState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate oauth state: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
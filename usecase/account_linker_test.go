@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+type unusedOAuthProvider struct{ OAuthProvider }
+
+type unusedLinkStateStore struct{ LinkStateStore }
+
+func TestAccountLinkerWithoutTokenStore(t *testing.T) {
+	al := NewAccountLinker(nil, nil, unusedOAuthProvider{}, unusedLinkStateStore{})
+	ctx := context.Background()
+
+	if al.LinkingEnabled() || al.OAuthEnabled() {
+		t.Errorf("LinkingEnabled = %v, OAuthEnabled = %v; want both disabled", al.LinkingEnabled(), al.OAuthEnabled())
+	}
+	if _, err := al.Token(ctx, "U1"); !errors.Is(err, ErrAccountNotLinked) {
+		t.Errorf("Token error = %v, want ErrAccountNotLinked", err)
+	}
+	if _, err := al.Link(ctx, "U1", "token"); !errors.Is(err, ErrLinkingDisabled) {
+		t.Errorf("Link error = %v, want ErrLinkingDisabled", err)
+	}
+	if _, err := al.StartLink(ctx, "U1"); !errors.Is(err, ErrOAuthNotConfigured) {
+		t.Errorf("StartLink error = %v, want ErrOAuthNotConfigured", err)
+	}
+}
@@ -3,6 +3,8 @@ package usecase
 import (
 	"context"
 	"fmt"
+	"log/slog"
+	"sync"
 
 	"github.com/monchh/annict-slack-bot/domain/entity"
 	"github.com/monchh/annict-slack-bot/pkg/jst"
@@ -17,18 +19,35 @@ type ProgramRepository interface {
 	FetchLibraryEntries(ctx context.Context) ([]*entity.Program, error)
 }
 
+// defaultImageWorkers is used when no positive worker count is given.
+const defaultImageWorkers = 8
+
 // AnnictInfoGetter defines the use case for fetching today's programs.
 type AnnictInfoGetter struct {
-	repo      ProgramRepository
-	validator ImageValidationService
+	repo         ProgramRepository
+	images       *ImageFallback
+	imageWorkers int               // Upper bound of concurrent image lookups
+	streaming    StreamingProvider // Optional
 }
 
 // ImageValidationService defines the interface for validating image URLs.
+// Implementations must be safe for concurrent use.
 type ImageValidationService interface {
 	// ValidateURL checks if the URL points to a valid, non-redirecting image.
 	ValidateURL(ctx context.Context, url string) (isValid bool, validatedURL string)
 }
 
+// AnnictInfoGetterInput holds the options of the use case.
+type AnnictInfoGetterInput struct {
+	// Subscriptions limits the library entries to works streamed on one of these services.
+	// Empty means no filtering.
+	Subscriptions []string
+	// Channels filters today's programs by the user's channel preferences. Optional.
+	Channels *entity.ChannelPreferences
+	// IncludeRebroadcasts keeps rebroadcasts in today's programs; they are left out by default.
+	IncludeRebroadcasts bool
+}
+
 // AnnictInfoGetterOutput holds the output of the use case.
 type AnnictInfoGetterOutput struct {
 	LibraryEntries []*entity.Program
@@ -36,37 +55,42 @@ type AnnictInfoGetterOutput struct {
 }
 
 // NewAnnictInfoGetter creates a new instance of the use case.
-func NewAnnictInfoGetter(repo ProgramRepository, validator ImageValidationService) *AnnictInfoGetter {
+// imageWorkers bounds how many works have their image looked up at the same time.
+// streaming may be nil when no streaming data is configured.
+func NewAnnictInfoGetter(repo ProgramRepository, images *ImageFallback, imageWorkers int, streaming StreamingProvider) *AnnictInfoGetter {
+	if imageWorkers <= 0 {
+		imageWorkers = defaultImageWorkers
+	}
 	return &AnnictInfoGetter{
-		repo:      repo,
-		validator: validator,
+		repo:         repo,
+		images:       images,
+		imageWorkers: imageWorkers,
+		streaming:    streaming,
 	}
 }
 
-// Execute runs the use case logic.
-func (ag *AnnictInfoGetter) Execute(ctx context.Context) (*AnnictInfoGetterOutput, error) {
+// Execute runs the use case logic. input may be nil.
+func (ag *AnnictInfoGetter) Execute(ctx context.Context, input *AnnictInfoGetterInput) (*AnnictInfoGetterOutput, error) {
+	if input == nil {
+		input = &AnnictInfoGetterInput{}
+	}
 	// Call the repository method to get today programs.
 	programs, err := ag.repo.FetchTodayPrograms(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find recent unwatched programs: %w", err)
 	}
 
-	// Validate image URLs concurrently (optional optimization)
-	// For simplicity, we'll do it sequentially here.
-	var validatedPrograms []*entity.Program
+	var todayPrograms []*entity.Program
 	for _, p := range programs {
-		if p.StartTime.IsZero() || !jst.IsSameDate(p.StartTime, jst.Now()) {
+		if p.StartTime.IsZero() || !jst.IsSameBroadcastDay(p.StartTime, jst.Now()) {
 			continue
 		}
-		progToValidate := p // Create a copy to modify
-		if progToValidate.Work.ImageURL != nil && *progToValidate.Work.ImageURL != "" {
-			isValid, _ := ag.validator.ValidateURL(ctx, *progToValidate.Work.ImageURL)
-			if !isValid {
-				progToValidate.Work.ImageURL = nil // Invalidate the URL if check fails
-			}
+		if p.Rebroadcast && !input.IncludeRebroadcasts {
+			continue
 		}
-		validatedPrograms = append(validatedPrograms, progToValidate)
+		todayPrograms = append(todayPrograms, p)
 	}
+	todayPrograms = applyChannelPreferences(todayPrograms, input.Channels)
 
 	// Call the repository method to get unwatched programs.
 	libraryEntries, err := ag.repo.FetchLibraryEntries(ctx)
@@ -74,27 +98,66 @@ func (ag *AnnictInfoGetter) Execute(ctx context.Context) (*AnnictInfoGetterOutpu
 		return nil, fmt.Errorf("failed to find programs: %w", err)
 	}
 
-	var validatedLibraryEntries []*entity.Program
-	for _, l := range libraryEntries {
-		// Validate image URL
-		// If p is a pointer, progToValidate still points to the original.
-		// If modification of ImageURL is intended only for the output,
-		// a deep copy of 'p' or at least 'p.Work' would be needed here.
-		// For simplicity, assuming modification of the slice element is acceptable for now.
-		progToValidate := l
-		if progToValidate.Work.ImageURL != nil && *progToValidate.Work.ImageURL != "" {
-			isValid, _ := ag.validator.ValidateURL(ctx, *progToValidate.Work.ImageURL)
-			if !isValid {
-				progToValidate.Work.ImageURL = nil
+	ag.attachStreaming(ctx, todayPrograms)
+	ag.attachStreaming(ctx, libraryEntries)
+	if len(input.Subscriptions) > 0 {
+		var subscribed []*entity.Program
+		for _, l := range libraryEntries {
+			if streamsOnAny(&l.Work, input.Subscriptions) {
+				subscribed = append(subscribed, l)
 			}
 		}
-		validatedLibraryEntries = append(validatedLibraryEntries, progToValidate)
+		libraryEntries = subscribed
+	}
+
+	// Images of both lists are resolved together so that one pool serves them all.
+	var works []*entity.Work
+	for _, p := range append(append([]*entity.Program{}, todayPrograms...), libraryEntries...) {
+		works = append(works, &p.Work)
 	}
+	resolveImages(ctx, ag.images, ag.imageWorkers, works)
 
 	output := &AnnictInfoGetterOutput{
-		Programs:       validatedPrograms,
-		LibraryEntries: validatedLibraryEntries,
+		Programs:       todayPrograms,
+		LibraryEntries: libraryEntries,
 	}
 
 	return output, nil
 }
+
+// resolveImages picks the image of every work through the fallback chain with a
+// bounded pool of workers. Each work is touched by exactly one worker.
+func resolveImages(ctx context.Context, images *ImageFallback, workers int, works []*entity.Work) {
+	jobs := make(chan *entity.Work)
+	var wg sync.WaitGroup
+	for range min(workers, len(works)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for w := range jobs {
+				w.ImageURL = images.Resolve(ctx, w)
+			}
+		}()
+	}
+	for _, w := range works {
+		jobs <- w
+	}
+	close(jobs)
+	wg.Wait()
+}
+
+// attachStreaming fills in the streaming services of each program's work.
+// A failed lookup only leaves that work without streaming links.
+func (ag *AnnictInfoGetter) attachStreaming(ctx context.Context, programs []*entity.Program) {
+	if ag.streaming == nil {
+		return
+	}
+	for _, p := range programs {
+		services, err := ag.streaming.StreamingServices(ctx, &p.Work)
+		if err != nil {
+			slog.Warn(fmt.Sprintf("Failed to look up streaming services of %s: %v", p.Work.Title, err))
+			continue
+		}
+		p.Work.Streaming = services
+	}
+}
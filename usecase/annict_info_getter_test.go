@@ -0,0 +1,64 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// countingValidator accepts every URL and records how many lookups ran at the same time.
+type countingValidator struct {
+	mu      sync.Mutex
+	active  int
+	maxSeen int
+}
+
+func (v *countingValidator) ValidateURL(ctx context.Context, url string) (bool, string) {
+	v.mu.Lock()
+	v.active++
+	v.maxSeen = max(v.maxSeen, v.active)
+	v.mu.Unlock()
+
+	time.Sleep(5 * time.Millisecond)
+
+	v.mu.Lock()
+	v.active--
+	v.mu.Unlock()
+	return true, url
+}
+
+func TestResolveImagesBoundsConcurrency(t *testing.T) {
+	tests := []struct {
+		name    string
+		workers int
+		works   int
+	}{
+		{name: "more works than workers", workers: 3, works: 20},
+		{name: "fewer works than workers", workers: 8, works: 2},
+		{name: "single worker", workers: 1, works: 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := &countingValidator{}
+			works := make([]*entity.Work, tt.works)
+			for i := range works {
+				works[i] = &entity.Work{Title: fmt.Sprintf("work-%d", i), ImageCandidates: []string{fmt.Sprintf("https://example.com/%d.png", i)}}
+			}
+
+			resolveImages(context.Background(), NewImageFallback(validator, nil, nil), tt.workers, works)
+
+			if validator.maxSeen > tt.workers {
+				t.Errorf("%d lookups ran at the same time, want at most %d", validator.maxSeen, tt.workers)
+			}
+			for i, w := range works {
+				if w.ImageURL == nil || *w.ImageURL != w.ImageCandidates[0] {
+					t.Errorf("work %d image = %v, want %s", i, w.ImageURL, w.ImageCandidates[0])
+				}
+			}
+		})
+	}
+}
@@ -1,5 +1,191 @@
 package annictcmd
 
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+)
+
 const (
 	ANNICT_TODAY = "annict_today"
 )
+
+// Command prefix and subcommand names understood by the registry.
+const (
+	COMMAND_PREFIX = "annict"
+	CMD_TODAY      = "today"
+	CMD_WEEK       = "week"
+	CMD_STATUS     = "status"
+	CMD_HELP       = "help"
+	CMD_LINK       = "link"
+	CMD_UNLINK     = "unlink"
+	CMD_SERVICES   = "services"
+	CMD_CHANNELS   = "channels"
+	CMD_SEARCH     = "search"
+	CMD_SEASON     = "season"
+	CMD_PREVIEW    = "preview"
+)
+
+// Block Kit action IDs shared by the presenter and the bot's interaction handler.
+const (
+	ACTION_RECORD_EPISODE = "annict_record_episode"
+	ACTION_UPDATE_STATUS  = "annict_update_status"
+	ACTION_OPEN_LINK      = "annict_open_link"
+	// Adds a work found by a search to the library as WANNA_WATCH. The value is the work ID.
+	ACTION_ADD_WANNA_WATCH = "annict_add_wanna_watch"
+	// Opens the channel preferences modal.
+	ACTION_OPEN_CHANNEL_PREFERENCES = "annict_open_channel_preferences"
+	// Page through a season lineup. The value is created by EncodeSeasonPageValue.
+	ACTION_SEASON_PREV_PAGE = "annict_season_prev_page"
+	ACTION_SEASON_NEXT_PAGE = "annict_season_next_page"
+	// Posts the rest of today's programs that did not fit in the message.
+	// The value is created by EncodeProgramsPageValue.
+	ACTION_SHOW_MORE_PROGRAMS = "annict_show_more_programs"
+	// URL buttons still send a block action, which the bot ignores.
+	ACTION_OPEN_AUTHORIZE_URL = "annict_open_authorize_url"
+)
+
+// Block Kit view, block and action IDs of the account link modal.
+const (
+	VIEW_LINK_ACCOUNT       = "annict_link_account"
+	BLOCK_LINK_TOKEN        = "annict_link_token"
+	ACTION_LINK_TOKEN_INPUT = "annict_link_token_input"
+)
+
+// Block Kit view, block and action IDs of the channel preferences modal.
+// The selects load their options through block_suggestion payloads.
+const (
+	VIEW_CHANNEL_PREFERENCES       = "annict_channel_preferences"
+	BLOCK_CHANNEL_ALLOWED          = "annict_channel_allowed"
+	ACTION_CHANNEL_ALLOWED_SELECT  = "annict_channel_allowed_select"
+	BLOCK_CHANNEL_PRIORITY         = "annict_channel_priority"
+	ACTION_CHANNEL_PRIORITY_SELECT = "annict_channel_priority_select"
+)
+
+// Flags understood by the schedule and search commands.
+const (
+	FLAG_REBROADCAST = "rebroadcast"
+	// Orders search results by watchers count.
+	FLAG_POPULAR = "popular"
+)
+
+// Subcommands of "annict channels".
+const (
+	CHANNELS_ALLOW  = "allow"
+	CHANNELS_PREFER = "prefer"
+	CHANNELS_CLEAR  = "clear"
+)
+
+const statusActionValueSeparator = ":"
+
+// EncodeStatusActionValue packs a work ID and a status into a Block Kit option value.
+func EncodeStatusActionValue(workID, status string) string {
+	return workID + statusActionValueSeparator + status
+}
+
+// DecodeStatusActionValue unpacks a value created by EncodeStatusActionValue.
+func DecodeStatusActionValue(value string) (workID, status string, err error) {
+	idx := strings.LastIndex(value, statusActionValueSeparator)
+	if idx <= 0 || idx == len(value)-1 {
+		return "", "", fmt.Errorf("invalid status action value: %q", value)
+	}
+	return value[:idx], value[idx+1:], nil
+}
+
+// channelOptionValueSeparator separates the Annict ID from the name in a channel option value.
+// The ID comes first, so names containing it are still decoded correctly.
+const channelOptionValueSeparator = ":"
+
+// EncodeChannelOptionValue packs an Annict channel ID and name into a Block Kit option value.
+func EncodeChannelOptionValue(annictID int64, name string) string {
+	return strconv.FormatInt(annictID, 10) + channelOptionValueSeparator + name
+}
+
+// DecodeChannelOptionValue unpacks a value created by EncodeChannelOptionValue.
+func DecodeChannelOptionValue(value string) (annictID int64, name string, err error) {
+	idx := strings.Index(value, channelOptionValueSeparator)
+	if idx <= 0 || idx == len(value)-1 {
+		return 0, "", fmt.Errorf("invalid channel option value: %q", value)
+	}
+	annictID, err = strconv.ParseInt(value[:idx], 10, 64)
+	if err != nil {
+		return 0, "", fmt.Errorf("invalid channel option value: %q", value)
+	}
+	return annictID, value[idx+1:], nil
+}
+
+// seasonPageSeparator separates the seasons from the cursors in a season page value.
+// Annict's cursors are base64, so neither it nor "," occurs in them.
+const seasonPageSeparator = "|"
+
+// EncodeSeasonPageValue packs the seasons of a lineup and the cursors of the pages
+// before the target page into a button value. No cursors means the first page.
+func EncodeSeasonPageValue(seasons, cursors []string) string {
+	return strings.Join(seasons, ",") + seasonPageSeparator + strings.Join(cursors, ",")
+}
+
+// DecodeSeasonPageValue unpacks a value created by EncodeSeasonPageValue.
+func DecodeSeasonPageValue(value string) (seasons, cursors []string, err error) {
+	rawSeasons, rawCursors, ok := strings.Cut(value, seasonPageSeparator)
+	if !ok || rawSeasons == "" {
+		return nil, nil, fmt.Errorf("invalid season page value: %q", value)
+	}
+	seasons = strings.Split(rawSeasons, ",")
+	if rawCursors != "" {
+		cursors = strings.Split(rawCursors, ",")
+	}
+	return seasons, cursors, nil
+}
+
+// ProgramsPage identifies the rest of a list of today's programs, so that it can be
+// fetched again when the "もっと見る" button is clicked. The rest is found by the first
+// program left out rather than by its position, since programs drop out of the list
+// as they are watched.
+type ProgramsPage struct {
+	Owner        string    // Slack user whose account and settings produced the list; empty for the default account
+	Date         string    // Broadcast day of the list, formatted by jst.FormatDate
+	Rebroadcasts bool      // Whether the list includes rebroadcasts
+	FirstID      string    // ID of the first program not shown yet
+	FirstStart   time.Time // Start time of that program, to find the rest once it is no longer listed
+}
+
+// programsPageSeparator separates the fields of a programs page value.
+// Slack user IDs, dates and Annict IDs never contain it.
+const programsPageSeparator = "|"
+
+// EncodeProgramsPageValue packs a programs page into a button value.
+func EncodeProgramsPageValue(page ProgramsPage) string {
+	return strings.Join([]string{
+		page.Owner,
+		page.Date,
+		strconv.FormatBool(page.Rebroadcasts),
+		page.FirstID,
+		strconv.FormatInt(page.FirstStart.Unix(), 10),
+	}, programsPageSeparator)
+}
+
+// DecodeProgramsPageValue unpacks a value created by EncodeProgramsPageValue.
+func DecodeProgramsPageValue(value string) (ProgramsPage, error) {
+	fields := strings.Split(value, programsPageSeparator)
+	if len(fields) != 5 || fields[1] == "" || fields[3] == "" {
+		return ProgramsPage{}, fmt.Errorf("invalid programs page value: %q", value)
+	}
+	rebroadcasts, err := strconv.ParseBool(fields[2])
+	if err != nil {
+		return ProgramsPage{}, fmt.Errorf("invalid programs page value: %q", value)
+	}
+	firstStart, err := strconv.ParseInt(fields[4], 10, 64)
+	if err != nil {
+		return ProgramsPage{}, fmt.Errorf("invalid programs page value: %q", value)
+	}
+	return ProgramsPage{
+		Owner:        fields[0],
+		Date:         fields[1],
+		Rebroadcasts: rebroadcasts,
+		FirstID:      fields[3],
+		FirstStart:   time.Unix(firstStart, 0).In(jst.Location()),
+	}, nil
+}
@@ -0,0 +1,46 @@
+package annictcmd
+
+import (
+	"testing"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+)
+
+func TestProgramsPageValue(t *testing.T) {
+	page := ProgramsPage{
+		Owner:        "U123",
+		Date:         "2026/10/16",
+		Rebroadcasts: true,
+		FirstID:      "UHJvZ3JhbS0xMjM0NQ==",
+		FirstStart:   time.Date(2026, time.October, 17, 1, 30, 0, 0, jst.Location()),
+	}
+	got, err := DecodeProgramsPageValue(EncodeProgramsPageValue(page))
+	if err != nil {
+		t.Fatalf("DecodeProgramsPageValue returned error: %v", err)
+	}
+	if got.Owner != page.Owner || got.Date != page.Date || got.Rebroadcasts != page.Rebroadcasts ||
+		got.FirstID != page.FirstID || !got.FirstStart.Equal(page.FirstStart) {
+		t.Errorf("got = %+v, want %+v", got, page)
+	}
+}
+
+func TestDecodeProgramsPageValueErrors(t *testing.T) {
+	tests := []struct {
+		name  string
+		value string
+	}{
+		{name: "offset of an older button", value: "U123|2026/10/16|false|20"},
+		{name: "missing date", value: "U123||false|UHJvZ3JhbS0x|1792166400"},
+		{name: "missing program", value: "U123|2026/10/16|false||1792166400"},
+		{name: "bad flag", value: "U123|2026/10/16|maybe|UHJvZ3JhbS0x|1792166400"},
+		{name: "bad start time", value: "U123|2026/10/16|false|UHJvZ3JhbS0x|tonight"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if page, err := DecodeProgramsPageValue(tt.value); err == nil {
+				t.Errorf("DecodeProgramsPageValue(%q) = %+v, want an error", tt.value, page)
+			}
+		})
+	}
+}
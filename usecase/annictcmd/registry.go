@@ -0,0 +1,327 @@
+package annictcmd
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// flagPrefix marks a word as a flag.
+const flagPrefix = "--"
+
+// Handler executes a parsed command.
+type Handler func(ctx context.Context, req *Request) error
+
+// Arg describes a positional argument of a command.
+type Arg struct {
+	Name     string
+	Help     string
+	Required bool
+	// Variadic consumes as many words as possible (e.g. titles containing spaces).
+	// At most one argument of a command may be variadic.
+	Variadic bool
+}
+
+// Flag describes a boolean option of a command, given anywhere as "--name".
+type Flag struct {
+	Name string
+	Help string
+}
+
+// Command describes a bot command and the handler that runs it.
+type Command struct {
+	Name    string
+	Aliases []string
+	Args    []Arg
+	Flags   []Flag
+	Help    string
+	// Personal commands answer slash commands only to the caller (an ephemeral reply).
+	// Mentions are always answered in the channel.
+	Personal bool
+	Handler  Handler
+}
+
+// Usage returns the command line syntax, e.g. "annict status <title...> <state>".
+func (c *Command) Usage() string {
+	parts := []string{COMMAND_PREFIX, c.Name}
+	for _, arg := range c.Args {
+		name := arg.Name
+		if arg.Variadic {
+			name += "..."
+		}
+		if arg.Required {
+			parts = append(parts, fmt.Sprintf("<%s>", name))
+		} else {
+			parts = append(parts, fmt.Sprintf("[%s]", name))
+		}
+	}
+	for _, flag := range c.Flags {
+		parts = append(parts, fmt.Sprintf("[%s%s]", flagPrefix, flag.Name))
+	}
+	return strings.Join(parts, " ")
+}
+
+// Origin describes who sent a command and where replies go.
+type Origin struct {
+	UserID    string
+	ChannelID string
+	// ThreadTS is the thread replies are posted in, empty for top-level replies.
+	ThreadTS string
+	// ResponseURL is set for slash commands. Replies go there, since the bot does not
+	// need to be a member of the channel a slash command is used in.
+	ResponseURL string
+}
+
+// Request holds a parsed command invocation.
+type Request struct {
+	Command *Command
+	Args    map[string]string
+	Flags   map[string]bool
+	Origin
+}
+
+// Arg returns the value of the named argument, or "" when it was omitted.
+func (r *Request) Arg(name string) string {
+	return r.Args[name]
+}
+
+// Flag reports whether the named flag was given.
+func (r *Request) Flag(name string) bool {
+	return r.Flags[name]
+}
+
+// UnknownCommandError is returned when the input does not match any registered command.
+type UnknownCommandError struct {
+	Input      string
+	Suggestion string // Closest command name, empty when nothing is close enough
+}
+
+func (e *UnknownCommandError) Error() string {
+	if e.Suggestion != "" {
+		return fmt.Sprintf("unknown command %q (did you mean %q?)", e.Input, e.Suggestion)
+	}
+	return fmt.Sprintf("unknown command %q", e.Input)
+}
+
+// UsageError is returned when the arguments do not satisfy the command's schema.
+type UsageError struct {
+	Command *Command
+	Reason  string
+}
+
+func (e *UsageError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Command.Usage(), e.Reason)
+}
+
+// Registry routes command text to registered handlers.
+type Registry struct {
+	commands map[string]*Command // keyed by name and aliases
+	ordered  []*Command
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		commands: make(map[string]*Command),
+	}
+}
+
+// Register adds a command. It panics on duplicate names or an invalid argument schema,
+// since both are programming errors.
+func (r *Registry) Register(cmd *Command) {
+	if cmd.Name == "" || cmd.Handler == nil {
+		panic("annictcmd: command requires a name and a handler")
+	}
+	variadic := 0
+	for _, arg := range cmd.Args {
+		if arg.Variadic {
+			variadic++
+		}
+	}
+	if variadic > 1 {
+		panic(fmt.Sprintf("annictcmd: command %q has more than one variadic argument", cmd.Name))
+	}
+	for _, key := range append([]string{cmd.Name}, cmd.Aliases...) {
+		key = strings.ToLower(key)
+		if _, exists := r.commands[key]; exists {
+			panic(fmt.Sprintf("annictcmd: duplicate command name %q", key))
+		}
+		r.commands[key] = cmd
+	}
+	r.ordered = append(r.ordered, cmd)
+}
+
+// Commands returns the registered commands sorted by name.
+func (r *Registry) Commands() []*Command {
+	commands := make([]*Command, len(r.ordered))
+	copy(commands, r.ordered)
+	sort.Slice(commands, func(i, j int) bool { return commands[i].Name < commands[j].Name })
+	return commands
+}
+
+// Lookup returns the command registered under the name or alias.
+func (r *Registry) Lookup(name string) (*Command, bool) {
+	cmd, ok := r.commands[strings.ToLower(name)]
+	return cmd, ok
+}
+
+// Parse resolves the command text (without the bot mention) into a request.
+// Both "annict today" and the legacy "annict_today" forms are accepted.
+func (r *Registry) Parse(text string) (*Request, error) {
+	words := strings.Fields(text)
+	if len(words) > 0 && strings.EqualFold(words[0], COMMAND_PREFIX) {
+		words = words[1:]
+	} else if len(words) > 0 && len(words[0]) > len(COMMAND_PREFIX)+1 && strings.EqualFold(words[0][:len(COMMAND_PREFIX)+1], COMMAND_PREFIX+"_") {
+		words[0] = words[0][len(COMMAND_PREFIX)+1:]
+	}
+	if len(words) == 0 {
+		words = []string{CMD_HELP}
+	}
+
+	cmd, ok := r.Lookup(words[0])
+	if !ok {
+		return nil, &UnknownCommandError{Input: words[0], Suggestion: r.suggest(words[0])}
+	}
+	words, flags, err := bindFlags(cmd, words[1:])
+	if err != nil {
+		return nil, err
+	}
+	args, err := bindArgs(cmd, words)
+	if err != nil {
+		return nil, err
+	}
+	return &Request{Command: cmd, Args: args, Flags: flags}, nil
+}
+
+// Dispatch parses the command text and runs the matching handler.
+func (r *Registry) Dispatch(ctx context.Context, text string, origin Origin) error {
+	req, err := r.Parse(text)
+	if err != nil {
+		return err
+	}
+	req.Origin = origin
+	return req.Command.Handler(ctx, req)
+}
+
+// bindFlags removes the command's flags from the words. Unknown flags are usage errors,
+// so commands without flags still accept words starting with "--" as arguments.
+func bindFlags(cmd *Command, words []string) ([]string, map[string]bool, error) {
+	flags := make(map[string]bool)
+	if len(cmd.Flags) == 0 {
+		return words, flags, nil
+	}
+	rest := make([]string, 0, len(words))
+	for _, word := range words {
+		name, ok := strings.CutPrefix(word, flagPrefix)
+		if !ok {
+			// Clients with smart punctuation turn "--" into an em dash.
+			name, ok = strings.CutPrefix(word, "—")
+		}
+		if !ok {
+			rest = append(rest, word)
+			continue
+		}
+		known := false
+		for _, flag := range cmd.Flags {
+			if strings.EqualFold(flag.Name, name) {
+				flags[flag.Name] = true
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, nil, &UsageError{Command: cmd, Reason: fmt.Sprintf("unknown flag %q", word)}
+		}
+	}
+	return rest, flags, nil
+}
+
+// bindArgs assigns the words to the command's positional arguments.
+func bindArgs(cmd *Command, words []string) (map[string]string, error) {
+	args := make(map[string]string, len(cmd.Args))
+	hasVariadic := false
+	for _, arg := range cmd.Args {
+		if arg.Variadic {
+			hasVariadic = true
+		}
+	}
+	if !hasVariadic && len(words) > len(cmd.Args) {
+		return nil, &UsageError{Command: cmd, Reason: "too many arguments"}
+	}
+
+	pos := 0
+	for i, arg := range cmd.Args {
+		if pos >= len(words) {
+			if arg.Required {
+				return nil, &UsageError{Command: cmd, Reason: fmt.Sprintf("missing argument <%s>", arg.Name)}
+			}
+			continue
+		}
+		if !arg.Variadic {
+			args[arg.Name] = words[pos]
+			pos++
+			continue
+		}
+		// Leave one word for every fixed argument that follows.
+		remainingFixed := 0
+		for _, next := range cmd.Args[i+1:] {
+			if !next.Variadic {
+				remainingFixed++
+			}
+		}
+		end := len(words) - remainingFixed
+		if end <= pos {
+			if arg.Required {
+				return nil, &UsageError{Command: cmd, Reason: fmt.Sprintf("missing argument <%s>", arg.Name)}
+			}
+			continue
+		}
+		args[arg.Name] = strings.Join(words[pos:end], " ")
+		pos = end
+	}
+	return args, nil
+}
+
+// suggest returns the registered name closest to the input, if it is close enough.
+func (r *Registry) suggest(input string) string {
+	input = strings.ToLower(input)
+	best := ""
+	bestDistance := -1
+	for name := range r.commands {
+		d := levenshtein(input, name)
+		if bestDistance < 0 || d < bestDistance || (d == bestDistance && name < best) {
+			best, bestDistance = name, d
+		}
+	}
+	threshold := len([]rune(input)) / 3
+	if threshold < 2 {
+		threshold = 2
+	}
+	if bestDistance < 0 || bestDistance > threshold {
+		return ""
+	}
+	return best
+}
+
+// levenshtein returns the edit distance between two strings.
+func levenshtein(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	prev := make([]int, len(rb)+1)
+	curr := make([]int, len(rb)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(ra); i++ {
+		curr[0] = i
+		for j := 1; j <= len(rb); j++ {
+			cost := 1
+			if ra[i-1] == rb[j-1] {
+				cost = 0
+			}
+			curr[j] = min(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(rb)]
+}
@@ -0,0 +1,228 @@
+package annictcmd
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func newTestRegistry() *Registry {
+	noop := func(ctx context.Context, req *Request) error { return nil }
+	r := NewRegistry()
+	r.Register(&Command{
+		Name:    CMD_TODAY,
+		Flags:   []Flag{{Name: FLAG_REBROADCAST}},
+		Handler: noop,
+	})
+	r.Register(&Command{
+		Name: CMD_STATUS,
+		Args: []Arg{
+			{Name: "title", Required: true, Variadic: true},
+			{Name: "state", Required: true},
+		},
+		Handler: noop,
+	})
+	r.Register(&Command{
+		Name: CMD_CHANNELS,
+		Args: []Arg{
+			{Name: "action"},
+			{Name: "channels", Variadic: true},
+		},
+		Handler: noop,
+	})
+	r.Register(&Command{
+		Name:    CMD_HELP,
+		Aliases: []string{"?"},
+		Handler: noop,
+	})
+	return r
+}
+
+func TestRegistryParse(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		wantCmd   string
+		wantArgs  map[string]string
+		wantFlags map[string]bool
+	}{
+		{
+			name:      "prefix and command",
+			text:      "annict today",
+			wantCmd:   CMD_TODAY,
+			wantArgs:  map[string]string{},
+			wantFlags: map[string]bool{},
+		},
+		{
+			name:      "legacy underscore form",
+			text:      "annict_today",
+			wantCmd:   CMD_TODAY,
+			wantArgs:  map[string]string{},
+			wantFlags: map[string]bool{},
+		},
+		{
+			name:      "without prefix, case insensitive",
+			text:      "TODAY",
+			wantCmd:   CMD_TODAY,
+			wantArgs:  map[string]string{},
+			wantFlags: map[string]bool{},
+		},
+		{
+			name:      "flag",
+			text:      "annict today --rebroadcast",
+			wantCmd:   CMD_TODAY,
+			wantArgs:  map[string]string{},
+			wantFlags: map[string]bool{FLAG_REBROADCAST: true},
+		},
+		{
+			name:      "flag typed as an em dash",
+			text:      "annict today —rebroadcast",
+			wantCmd:   CMD_TODAY,
+			wantArgs:  map[string]string{},
+			wantFlags: map[string]bool{FLAG_REBROADCAST: true},
+		},
+		{
+			name:      "variadic argument before a fixed one",
+			text:      "annict status 葬送の フリーレン watching",
+			wantCmd:   CMD_STATUS,
+			wantArgs:  map[string]string{"title": "葬送の フリーレン", "state": "watching"},
+			wantFlags: map[string]bool{},
+		},
+		{
+			name:      "optional arguments omitted",
+			text:      "annict channels",
+			wantCmd:   CMD_CHANNELS,
+			wantArgs:  map[string]string{},
+			wantFlags: map[string]bool{},
+		},
+		{
+			name:      "trailing variadic argument",
+			text:      "annict channels allow TOKYO MX, BS11",
+			wantCmd:   CMD_CHANNELS,
+			wantArgs:  map[string]string{"action": "allow", "channels": "TOKYO MX, BS11"},
+			wantFlags: map[string]bool{},
+		},
+		{
+			name:      "alias",
+			text:      "annict ?",
+			wantCmd:   CMD_HELP,
+			wantArgs:  map[string]string{},
+			wantFlags: map[string]bool{},
+		},
+		{
+			name:      "empty text shows help",
+			text:      "annict",
+			wantCmd:   CMD_HELP,
+			wantArgs:  map[string]string{},
+			wantFlags: map[string]bool{},
+		},
+	}
+	r := newTestRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, err := r.Parse(tt.text)
+			if err != nil {
+				t.Fatalf("Parse(%q) returned error: %v", tt.text, err)
+			}
+			if req.Command.Name != tt.wantCmd {
+				t.Errorf("command = %q, want %q", req.Command.Name, tt.wantCmd)
+			}
+			if !reflect.DeepEqual(req.Args, tt.wantArgs) {
+				t.Errorf("args = %v, want %v", req.Args, tt.wantArgs)
+			}
+			if !reflect.DeepEqual(req.Flags, tt.wantFlags) {
+				t.Errorf("flags = %v, want %v", req.Flags, tt.wantFlags)
+			}
+		})
+	}
+}
+
+func TestRegistryParseErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		text           string
+		wantUnknown    bool
+		wantSuggestion string
+		wantReason     string
+	}{
+		{name: "typo suggests the closest command", text: "annict tody", wantUnknown: true, wantSuggestion: CMD_TODAY},
+		{name: "nothing close enough", text: "annict zzzzzzzz", wantUnknown: true},
+		{name: "missing required argument", text: "annict status", wantReason: "missing argument <title>"},
+		{name: "variadic argument left empty", text: "annict status watching", wantReason: "missing argument <title>"},
+		{name: "too many arguments", text: "annict today extra", wantReason: "too many arguments"},
+		{name: "unknown flag", text: "annict today --all", wantReason: `unknown flag "--all"`},
+	}
+	r := newTestRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := r.Parse(tt.text)
+			if tt.wantUnknown {
+				var unknownErr *UnknownCommandError
+				if !errors.As(err, &unknownErr) {
+					t.Fatalf("Parse(%q) error = %v, want UnknownCommandError", tt.text, err)
+				}
+				if unknownErr.Suggestion != tt.wantSuggestion {
+					t.Errorf("suggestion = %q, want %q", unknownErr.Suggestion, tt.wantSuggestion)
+				}
+				return
+			}
+			var usageErr *UsageError
+			if !errors.As(err, &usageErr) {
+				t.Fatalf("Parse(%q) error = %v, want UsageError", tt.text, err)
+			}
+			if usageErr.Reason != tt.wantReason {
+				t.Errorf("reason = %q, want %q", usageErr.Reason, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestRegistryDispatchSetsOrigin(t *testing.T) {
+	var got *Request
+	r := NewRegistry()
+	r.Register(&Command{
+		Name:    CMD_TODAY,
+		Handler: func(ctx context.Context, req *Request) error { got = req; return nil },
+	})
+	origin := Origin{UserID: "U1", ChannelID: "C1", ThreadTS: "1.2"}
+	if err := r.Dispatch(context.Background(), "annict today", origin); err != nil {
+		t.Fatalf("Dispatch returned error: %v", err)
+	}
+	if got == nil || got.Origin != origin {
+		t.Errorf("handler got origin %+v, want %+v", got, origin)
+	}
+}
+
+func TestRegisterPanics(t *testing.T) {
+	noop := func(ctx context.Context, req *Request) error { return nil }
+	tests := []struct {
+		name string
+		cmd  *Command
+	}{
+		{name: "without handler", cmd: &Command{Name: "x"}},
+		{name: "duplicate name", cmd: &Command{Name: CMD_TODAY, Handler: noop}},
+		{name: "two variadic arguments", cmd: &Command{Name: "y", Args: []Arg{{Name: "a", Variadic: true}, {Name: "b", Variadic: true}}, Handler: noop}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register did not panic")
+				}
+			}()
+			newTestRegistry().Register(tt.cmd)
+		})
+	}
+}
+
+func TestCommandUsage(t *testing.T) {
+	cmd, _ := newTestRegistry().Lookup(CMD_STATUS)
+	if got, want := cmd.Usage(), "annict status <title...> <state>"; got != want {
+		t.Errorf("Usage() = %q, want %q", got, want)
+	}
+	cmd, _ = newTestRegistry().Lookup(CMD_TODAY)
+	if got, want := cmd.Usage(), "annict today [--rebroadcast]"; got != want {
+		t.Errorf("Usage() = %q, want %q", got, want)
+	}
+}
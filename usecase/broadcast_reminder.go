@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+)
+
+const (
+	// reminderCheckInterval is how often due reminders are checked.
+	reminderCheckInterval = 30 * time.Second
+	// reminderHistoryRetention is how long sent reminders are remembered.
+	reminderHistoryRetention = 7 * 24 * time.Hour
+)
+
+// ReminderNotifier defines the interface for delivering a reminder.
+// The implementation will reside in the infrastructure layer.
+type ReminderNotifier interface {
+	// NotifyUpcoming announces that the program starts soon.
+	NotifyUpcoming(ctx context.Context, program *entity.Program) error
+}
+
+// BroadcastReminder defines the use case for reminding upcoming broadcasts.
+// It keeps today's unwatched programs in memory, re-syncs them with Annict
+// periodically, and notifies each program at most once.
+type BroadcastReminder struct {
+	repo         ProgramRepository
+	notifier     ReminderNotifier
+	history      NotificationHistoryRepository
+	leadTime     time.Duration
+	syncInterval time.Duration
+	programs     []*entity.Program
+}
+
+// NewBroadcastReminder creates a new instance of the use case.
+func NewBroadcastReminder(
+	repo ProgramRepository,
+	notifier ReminderNotifier,
+	history NotificationHistoryRepository,
+	leadTime, syncInterval time.Duration,
+) *BroadcastReminder {
+	return &BroadcastReminder{
+		repo:         repo,
+		notifier:     notifier,
+		history:      history,
+		leadTime:     leadTime,
+		syncInterval: syncInterval,
+	}
+}
+
+// Run syncs and checks reminders until the context is cancelled.
+func (br *BroadcastReminder) Run(ctx context.Context) {
+	if err := br.sync(ctx); err != nil {
+		slog.Error(fmt.Sprintf("Reminder sync failed: %v", err))
+	}
+	syncTicker := time.NewTicker(br.syncInterval)
+	defer syncTicker.Stop()
+	checkTicker := time.NewTicker(reminderCheckInterval)
+	defer checkTicker.Stop()
+
+	br.check(ctx, jst.Now())
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-syncTicker.C:
+			if err := br.sync(ctx); err != nil {
+				slog.Error(fmt.Sprintf("Reminder sync failed: %v", err))
+			}
+		case <-checkTicker.C:
+			br.check(ctx, jst.Now())
+		}
+	}
+}
+
+// sync replaces the in-memory programs with the upcoming programs from Annict.
+// On failure the previous programs are kept.
+func (br *BroadcastReminder) sync(ctx context.Context) error {
+	programs, err := br.repo.FetchTodayPrograms(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to fetch programs for reminders: %w", err)
+	}
+
+	now := jst.Now()
+	horizon := now.Add(br.syncInterval + br.leadTime)
+	var upcoming []*entity.Program
+	for _, p := range programs {
+		// Cancelled programs will not air, so there is nothing to remind of.
+		if p.StartTime.IsZero() || !p.StartTime.After(now) || p.IsHidden() {
+			continue
+		}
+		if jst.IsSameBroadcastDay(p.StartTime, now) || p.StartTime.Before(horizon) {
+			upcoming = append(upcoming, p)
+		}
+	}
+	br.programs = upcoming
+	slog.Info(fmt.Sprintf("Reminder synced %d upcoming program(s)", len(upcoming)))
+
+	if err := br.history.PruneNotifications(ctx, entity.NotificationKindReminder, now.Add(-reminderHistoryRetention)); err != nil {
+		slog.Warn(fmt.Sprintf("Failed to prune reminder history: %v", err))
+	}
+	return nil
+}
+
+// check notifies every program whose start is within the lead time.
+func (br *BroadcastReminder) check(ctx context.Context, now time.Time) {
+	for _, p := range br.programs {
+		if now.Before(p.StartTime.Add(-br.leadTime)) || !now.Before(p.StartTime) {
+			continue
+		}
+		key := reminderKey(p)
+		_, reminded, err := br.history.FindNotification(ctx, entity.NotificationKindReminder, key)
+		if err != nil {
+			slog.Error(fmt.Sprintf("Failed to read reminder history for %s: %v", key, err))
+			continue
+		}
+		if reminded {
+			continue
+		}
+		// Mark first so that a crash or restart can never remind twice.
+		notification := &entity.Notification{Kind: entity.NotificationKindReminder, Key: key, SentAt: now}
+		if err := br.history.RecordNotification(ctx, notification); err != nil {
+			slog.Error(fmt.Sprintf("Failed to persist reminder history for %s, skipping: %v", key, err))
+			continue
+		}
+		if err := br.notifier.NotifyUpcoming(ctx, p); err != nil {
+			slog.Error(fmt.Sprintf("Failed to send reminder for %s: %v", key, err))
+		}
+	}
+}
+
+// reminderKey identifies a program across syncs and restarts.
+func reminderKey(p *entity.Program) string {
+	if p.ID != "" {
+		return p.ID
+	}
+	return fmt.Sprintf("%s/%s/%s", p.Work.Title, p.Episode.NumberText, p.StartTime.Format(time.RFC3339))
+}
@@ -0,0 +1,263 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// channelPreferencesKey is the user setting holding the channel preferences as JSON.
+const channelPreferencesKey = "channel_preferences"
+
+// ChannelPreferenceSettings defines the use case for storing a Slack user's channel preferences.
+type ChannelPreferenceSettings struct {
+	settings UserSettingsRepository
+}
+
+// NewChannelPreferenceSettings creates a new instance of the use case.
+func NewChannelPreferenceSettings(settings UserSettingsRepository) *ChannelPreferenceSettings {
+	return &ChannelPreferenceSettings{
+		settings: settings,
+	}
+}
+
+// Preferences returns the user's preferences, empty when none are set.
+func (cs *ChannelPreferenceSettings) Preferences(ctx context.Context, slackUserID string) (*entity.ChannelPreferences, error) {
+	prefs := &entity.ChannelPreferences{}
+	value, ok, err := cs.settings.GetUserSetting(ctx, slackUserID, channelPreferencesKey)
+	if err != nil || !ok {
+		return prefs, err
+	}
+	if err := json.Unmarshal([]byte(value), prefs); err != nil {
+		return nil, fmt.Errorf("failed to decode channel preferences of %s: %w", slackUserID, err)
+	}
+	return prefs, nil
+}
+
+// Save replaces the user's preferences. Empty preferences are deleted.
+func (cs *ChannelPreferenceSettings) Save(ctx context.Context, slackUserID string, prefs *entity.ChannelPreferences) error {
+	if prefs.IsEmpty() {
+		return cs.settings.DeleteUserSetting(ctx, slackUserID, channelPreferencesKey)
+	}
+	value, err := json.Marshal(prefs)
+	if err != nil {
+		return fmt.Errorf("failed to encode channel preferences: %w", err)
+	}
+	return cs.settings.SetUserSetting(ctx, slackUserID, channelPreferencesKey, string(value))
+}
+
+// UnknownChannelError is returned when a channel name matches none of the viewer's channels.
+type UnknownChannelError struct {
+	Name        string
+	Suggestions []string // Known channel names containing the input, if any
+}
+
+func (e *UnknownChannelError) Error() string {
+	if len(e.Suggestions) > 0 {
+		return fmt.Sprintf("unknown channel %q (did you mean: %s?)", e.Name, strings.Join(e.Suggestions, ", "))
+	}
+	return fmt.Sprintf("unknown channel %q", e.Name)
+}
+
+// knownChannelsRetention is how long the known channels are kept after the last listing.
+const knownChannelsRetention = 90 * 24 * time.Hour
+
+// ChannelLister defines the use case for listing the channels of the viewer's programs,
+// which are the channels the viewer registered on Annict.
+type ChannelLister struct {
+	repo  ProgramRepository
+	known CacheRepository // Optional
+	key   string
+}
+
+// NewChannelLister creates a new instance of the use case. known may be nil; with it,
+// every channel listed once is remembered under the key (which identifies the viewer's
+// account), so channels without a program at the moment stay selectable and Known can
+// answer without calling Annict.
+func NewChannelLister(repo ProgramRepository, known CacheRepository, key string) *ChannelLister {
+	return &ChannelLister{
+		repo:  repo,
+		known: known,
+		key:   "channels:" + key,
+	}
+}
+
+// Execute returns the distinct channels of the viewer's upcoming programs and library,
+// together with the channels known from earlier listings, ordered by channel group and name.
+func (cl *ChannelLister) Execute(ctx context.Context) ([]entity.Channel, error) {
+	programs, err := cl.repo.FetchTodayPrograms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find programs: %w", err)
+	}
+	entries, err := cl.repo.FetchLibraryEntries(ctx)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("Failed to find library entries for the channel list: %v", err))
+	}
+
+	channels := cl.Known(ctx)
+	grown := false
+	for _, p := range append(programs, entries...) {
+		var added bool
+		channels, added = addChannel(channels, p.Channel)
+		grown = grown || added
+	}
+	sortChannels(channels)
+	if grown {
+		cl.remember(ctx, channels)
+	}
+	return channels, nil
+}
+
+// Known returns the channels remembered from earlier listings without calling Annict.
+// It is empty until Execute has run once.
+func (cl *ChannelLister) Known(ctx context.Context) []entity.Channel {
+	if cl.known == nil {
+		return nil
+	}
+	entry, ok, err := cl.known.GetCache(ctx, cl.key)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("Failed to read known channels: %v", err))
+		return nil
+	}
+	if !ok {
+		return nil
+	}
+	var channels []entity.Channel
+	if err := json.Unmarshal(entry.Value, &channels); err != nil {
+		slog.Warn(fmt.Sprintf("Failed to decode known channels: %v", err))
+		return nil
+	}
+	return channels
+}
+
+func (cl *ChannelLister) remember(ctx context.Context, channels []entity.Channel) {
+	if cl.known == nil {
+		return
+	}
+	value, err := json.Marshal(channels)
+	if err != nil {
+		slog.Warn(fmt.Sprintf("Failed to encode known channels: %v", err))
+		return
+	}
+	now := time.Now()
+	entry := &CacheEntry{Value: value, StoredAt: now, ExpiresAt: now.Add(knownChannelsRetention)}
+	if err := cl.known.SetCache(ctx, cl.key, entry); err != nil {
+		slog.Warn(fmt.Sprintf("Failed to store known channels: %v", err))
+	}
+}
+
+// addChannel appends the channel unless it is already listed. A listed channel
+// without an Annict ID is replaced by the same channel with one.
+func addChannel(channels []entity.Channel, ch entity.Channel) ([]entity.Channel, bool) {
+	if ch.Name == "" {
+		return channels, false
+	}
+	for i, known := range channels {
+		if known.Matches(ch) {
+			if known.AnnictID == 0 && ch.AnnictID != 0 {
+				channels[i] = ch
+				return channels, true
+			}
+			return channels, false
+		}
+	}
+	return append(channels, ch), true
+}
+
+func sortChannels(channels []entity.Channel) {
+	sort.SliceStable(channels, func(i, j int) bool {
+		if channels[i].GroupName != channels[j].GroupName {
+			return channels[i].GroupName < channels[j].GroupName
+		}
+		return channels[i].Name < channels[j].Name
+	})
+}
+
+// Resolve matches the names against the viewer's channels, exactly first and then partially.
+// Names that match nothing or several channels result in an UnknownChannelError.
+func (cl *ChannelLister) Resolve(ctx context.Context, names []string) ([]entity.Channel, error) {
+	channels, err := cl.Execute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var resolved []entity.Channel
+	for _, name := range names {
+		query := normalizeTitle(name)
+		if query == "" {
+			continue
+		}
+		var exact *entity.Channel
+		var partial []entity.Channel
+		for i, ch := range channels {
+			normalized := normalizeTitle(ch.Name)
+			if normalized == query {
+				exact = &channels[i]
+				break
+			}
+			if strings.Contains(normalized, query) {
+				partial = append(partial, ch)
+			}
+		}
+		switch {
+		case exact != nil:
+			resolved = append(resolved, *exact)
+		case len(partial) == 1:
+			resolved = append(resolved, partial[0])
+		default:
+			unknownErr := &UnknownChannelError{Name: name}
+			for _, ch := range partial {
+				unknownErr.Suggestions = append(unknownErr.Suggestions, ch.Name)
+			}
+			return nil, unknownErr
+		}
+	}
+	return resolved, nil
+}
+
+// applyChannelPreferences drops programs on channels the user did not allow and,
+// when an episode airs on several channels, keeps only the most preferred airing
+// (the earliest one among equally preferred channels). The order is preserved.
+func applyChannelPreferences(programs []*entity.Program, prefs *entity.ChannelPreferences) []*entity.Program {
+	if prefs.IsEmpty() {
+		return programs
+	}
+	best := make(map[string]*entity.Program)
+	var allowed []*entity.Program
+	for _, p := range programs {
+		if !prefs.Allows(p.Channel) {
+			continue
+		}
+		allowed = append(allowed, p)
+		key := episodeKey(p)
+		current, ok := best[key]
+		if !ok {
+			best[key] = p
+			continue
+		}
+		rank, currentRank := prefs.Rank(p.Channel), prefs.Rank(current.Channel)
+		if rank < currentRank || (rank == currentRank && p.StartTime.Before(current.StartTime)) {
+			best[key] = p
+		}
+	}
+	var filtered []*entity.Program
+	for _, p := range allowed {
+		if best[episodeKey(p)] == p {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered
+}
+
+// episodeKey identifies the episode a program broadcasts.
+func episodeKey(p *entity.Program) string {
+	if p.Episode.ID != "" {
+		return p.Episode.ID
+	}
+	return p.Work.Title + "/" + p.Episode.NumberText
+}
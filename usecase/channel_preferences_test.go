@@ -0,0 +1,141 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// fakeProgramRepository serves fixed programs and library entries.
+type fakeProgramRepository struct {
+	programs   []*entity.Program
+	entries    []*entity.Program
+	entriesErr error
+}
+
+func (r *fakeProgramRepository) FetchTodayPrograms(ctx context.Context) ([]*entity.Program, error) {
+	return r.programs, nil
+}
+
+func (r *fakeProgramRepository) FetchLibraryEntries(ctx context.Context) ([]*entity.Program, error) {
+	return r.entries, r.entriesErr
+}
+
+// mapCache is an in-memory CacheRepository.
+type mapCache map[string]*CacheEntry
+
+func (c mapCache) GetCache(ctx context.Context, key string) (*CacheEntry, bool, error) {
+	entry, ok := c[key]
+	return entry, ok, nil
+}
+
+func (c mapCache) SetCache(ctx context.Context, key string, entry *CacheEntry) error {
+	c[key] = entry
+	return nil
+}
+
+func (c mapCache) DeleteCache(ctx context.Context, key string) error { return nil }
+
+func (c mapCache) DeleteCacheByPrefix(ctx context.Context, prefix string) error { return nil }
+
+func (c mapCache) PruneCache(ctx context.Context, prefix string, before time.Time) error { return nil }
+
+func onChannel(ch entity.Channel) *entity.Program {
+	return &entity.Program{Channel: ch}
+}
+
+func channelNames(channels []entity.Channel) []string {
+	var names []string
+	for _, ch := range channels {
+		names = append(names, ch.Name)
+	}
+	return names
+}
+
+var (
+	tokyoMX = entity.Channel{AnnictID: 1, Name: "TOKYO MX", GroupName: "地上波"}
+	bs11    = entity.Channel{AnnictID: 2, Name: "BS11", GroupName: "BS"}
+	nhk     = entity.Channel{AnnictID: 3, Name: "NHK総合", GroupName: "地上波"}
+)
+
+func TestChannelListerExecute(t *testing.T) {
+	tests := []struct {
+		name       string
+		known      []entity.Channel
+		programs   []*entity.Program
+		entries    []*entity.Program
+		entriesErr error
+		want       []string
+	}{
+		{
+			name:     "programs and library entries are merged and sorted",
+			programs: []*entity.Program{onChannel(tokyoMX), onChannel(tokyoMX), onChannel(entity.Channel{})},
+			entries:  []*entity.Program{onChannel(bs11)},
+			want:     []string{"BS11", "TOKYO MX"},
+		},
+		{
+			name:     "known channels stay listed without programs",
+			known:    []entity.Channel{nhk},
+			programs: []*entity.Program{onChannel(bs11)},
+			want:     []string{"BS11", "NHK総合"},
+		},
+		{
+			name:       "library errors do not fail the listing",
+			programs:   []*entity.Program{onChannel(tokyoMX)},
+			entriesErr: errors.New("annict is down"),
+			want:       []string{"TOKYO MX"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			known := mapCache{}
+			repo := &fakeProgramRepository{programs: tt.programs, entries: tt.entries, entriesErr: tt.entriesErr}
+			cl := NewChannelLister(repo, known, "account")
+			if tt.known != nil {
+				cl.remember(context.Background(), tt.known)
+			}
+
+			channels, err := cl.Execute(context.Background())
+			if err != nil {
+				t.Fatalf("Execute returned error: %v", err)
+			}
+			if got := channelNames(channels); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("channels = %v, want %v", got, tt.want)
+			}
+			// Known answers from the cache alone, even once Annict has nothing scheduled.
+			repo.programs, repo.entries = nil, nil
+			if got := channelNames(cl.Known(context.Background())); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("known channels = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChannelListerReplacesChannelWithoutID(t *testing.T) {
+	known := mapCache{}
+	cl := NewChannelLister(&fakeProgramRepository{programs: []*entity.Program{onChannel(tokyoMX)}}, known, "account")
+	cl.remember(context.Background(), []entity.Channel{{Name: "tokyo mx"}})
+
+	channels, err := cl.Execute(context.Background())
+	if err != nil {
+		t.Fatalf("Execute returned error: %v", err)
+	}
+	if !reflect.DeepEqual(channels, []entity.Channel{tokyoMX}) {
+		t.Errorf("channels = %+v, want only %+v", channels, tokyoMX)
+	}
+}
+
+func TestChannelListerWithoutCache(t *testing.T) {
+	cl := NewChannelLister(&fakeProgramRepository{programs: []*entity.Program{onChannel(bs11)}}, nil, "account")
+	channels, err := cl.Execute(context.Background())
+	if err != nil || !reflect.DeepEqual(channelNames(channels), []string{"BS11"}) {
+		t.Errorf("Execute = %v, %v; want [BS11]", channelNames(channels), err)
+	}
+	if known := cl.Known(context.Background()); known != nil {
+		t.Errorf("Known = %v without a cache, want nil", known)
+	}
+}
@@ -0,0 +1,91 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+)
+
+// DailyDigestLog defines the use case for remembering the daily digest message of each
+// channel, so that later runs on the same broadcast day update it instead of posting again.
+type DailyDigestLog struct {
+	history NotificationHistoryRepository
+}
+
+// NewDailyDigestLog creates a new instance of the use case.
+func NewDailyDigestLog(history NotificationHistoryRepository) *DailyDigestLog {
+	return &DailyDigestLog{
+		history: history,
+	}
+}
+
+// Find returns the timestamp of the digest posted to the channel on the broadcast day of now,
+// or "" when none has been posted yet.
+func (dl *DailyDigestLog) Find(ctx context.Context, channelID string, now time.Time) (string, error) {
+	notification, ok, err := dl.history.FindNotification(ctx, entity.NotificationKindDailyDigest, dailyDigestKey(channelID, now))
+	if err != nil {
+		return "", fmt.Errorf("failed to read daily digest of %s: %w", channelID, err)
+	}
+	if !ok {
+		return "", nil
+	}
+	return notification.MessageTS, nil
+}
+
+// Record remembers the digest posted to the channel on the broadcast day of now.
+func (dl *DailyDigestLog) Record(ctx context.Context, channelID, messageTS string, now time.Time) error {
+	notification := &entity.Notification{
+		Kind:      entity.NotificationKindDailyDigest,
+		Key:       dailyDigestKey(channelID, now),
+		ChannelID: channelID,
+		MessageTS: messageTS,
+		SentAt:    now,
+	}
+	if err := dl.history.RecordNotification(ctx, notification); err != nil {
+		return fmt.Errorf("failed to record daily digest of %s: %w", channelID, err)
+	}
+	return nil
+}
+
+// MarkExpanded remembers that the rest of the programs of the digest posted as messageTS
+// was posted, so that later updates leave out its "もっと見る" button. Messages other than
+// the channel's digest of the broadcast day of now are ignored.
+func (dl *DailyDigestLog) MarkExpanded(ctx context.Context, channelID, messageTS string, now time.Time) error {
+	digestTS, err := dl.Find(ctx, channelID, now)
+	if err != nil {
+		return err
+	}
+	if digestTS == "" || digestTS != messageTS {
+		return nil
+	}
+	notification := &entity.Notification{
+		Kind:      entity.NotificationKindDailyDigestExpanded,
+		Key:       dailyDigestKey(channelID, now),
+		ChannelID: channelID,
+		MessageTS: messageTS,
+		SentAt:    now,
+	}
+	if err := dl.history.RecordNotification(ctx, notification); err != nil {
+		return fmt.Errorf("failed to record expanded daily digest of %s: %w", channelID, err)
+	}
+	return nil
+}
+
+// Expanded reports whether the rest of the programs of the digest posted as messageTS
+// on the broadcast day of now was posted.
+func (dl *DailyDigestLog) Expanded(ctx context.Context, channelID, messageTS string, now time.Time) (bool, error) {
+	notification, ok, err := dl.history.FindNotification(ctx, entity.NotificationKindDailyDigestExpanded, dailyDigestKey(channelID, now))
+	if err != nil {
+		return false, fmt.Errorf("failed to read expanded daily digest of %s: %w", channelID, err)
+	}
+	// A digest posted again after the expanded one was deleted still has its button.
+	return ok && notification.MessageTS == messageTS, nil
+}
+
+// dailyDigestKey identifies the digest of a channel on a broadcast day.
+func dailyDigestKey(channelID string, now time.Time) string {
+	return channelID + "/" + jst.FormatDate(jst.BroadcastDay(now))
+}
@@ -0,0 +1,44 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+)
+
+func TestDailyDigestLogExpanded(t *testing.T) {
+	morning := time.Date(2026, time.October, 16, 7, 0, 0, 0, jst.Location())
+	tests := []struct {
+		name      string
+		clickedTS string    // Message whose "もっと見る" button was clicked
+		checkTS   string    // Digest checked before the update
+		checkAt   time.Time // Time of the update
+		want      bool
+	}{
+		{name: "expanded digest", clickedTS: "1.0", checkTS: "1.0", checkAt: morning.Add(time.Hour), want: true},
+		{name: "other message of the channel", clickedTS: "2.0", checkTS: "1.0", checkAt: morning.Add(time.Hour), want: false},
+		{name: "digest posted again", clickedTS: "1.0", checkTS: "3.0", checkAt: morning.Add(time.Hour), want: false},
+		{name: "next broadcast day", clickedTS: "1.0", checkTS: "1.0", checkAt: morning.AddDate(0, 0, 1), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			dl := NewDailyDigestLog(memoryHistory{})
+			if err := dl.Record(ctx, "C1", "1.0", morning); err != nil {
+				t.Fatalf("Record returned error: %v", err)
+			}
+			if err := dl.MarkExpanded(ctx, "C1", tt.clickedTS, morning.Add(30*time.Minute)); err != nil {
+				t.Fatalf("MarkExpanded returned error: %v", err)
+			}
+			got, err := dl.Expanded(ctx, "C1", tt.checkTS, tt.checkAt)
+			if err != nil {
+				t.Fatalf("Expanded returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expanded = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+)
+
+// RecordRepository defines the interface for writing watch records.
+// The implementation will reside in the interfaces layer.
+type RecordRepository interface {
+	// CreateRecord marks the given episode as watched for the viewer.
+	CreateRecord(ctx context.Context, episodeID string) error
+}
+
+// EpisodeRecorder defines the use case for recording an episode as watched.
+type EpisodeRecorder struct {
+	repo RecordRepository
+}
+
+// NewEpisodeRecorder creates a new instance of the use case.
+func NewEpisodeRecorder(repo RecordRepository) *EpisodeRecorder {
+	return &EpisodeRecorder{
+		repo: repo,
+	}
+}
+
+// Execute runs the use case logic.
+func (er *EpisodeRecorder) Execute(ctx context.Context, episodeID string) error {
+	if episodeID == "" {
+		return fmt.Errorf("episode ID is empty")
+	}
+	if err := er.repo.CreateRecord(ctx, episodeID); err != nil {
+		return fmt.Errorf("failed to record episode %s: %w", episodeID, err)
+	}
+	return nil
+}
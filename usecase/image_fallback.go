@@ -0,0 +1,81 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// OGImageFetcher defines the interface for finding the Open Graph image of a web page.
+// The implementation will reside in the interfaces layer.
+type OGImageFetcher interface {
+	// FetchOGImage returns the og:image URL of the page, or "" when it has none.
+	FetchOGImage(ctx context.Context, pageURL string) (string, error)
+}
+
+// ImageProxy defines the interface of the bot's own image endpoint.
+// The implementation will reside in the infrastructure layer.
+type ImageProxy interface {
+	// ProxyURL returns a stable URL serving a resized, cached copy of the image.
+	// The seed picks the placeholder shown when the image cannot be loaded.
+	ProxyURL(imageURL, seed string) string
+	// PlaceholderURL returns the URL of an image generated for a work without any picture.
+	PlaceholderURL(seed string) string
+}
+
+// ImageFallback picks the image shown for a work by trying, in order, the images
+// offered by Annict, the og:image of the official site and a generated placeholder.
+type ImageFallback struct {
+	validator ImageValidationService
+	ogFetcher OGImageFetcher // Optional
+	proxy     ImageProxy     // Optional
+}
+
+// NewImageFallback creates the fallback chain. ogFetcher and proxy may be nil;
+// without a proxy there is no placeholder and works without a valid image get none.
+func NewImageFallback(validator ImageValidationService, ogFetcher OGImageFetcher, proxy ImageProxy) *ImageFallback {
+	return &ImageFallback{
+		validator: validator,
+		ogFetcher: ogFetcher,
+		proxy:     proxy,
+	}
+}
+
+// Resolve returns the image URL to show for the work, or nil when there is none.
+func (f *ImageFallback) Resolve(ctx context.Context, work *entity.Work) *string {
+	candidates := work.ImageCandidates
+	if len(candidates) == 0 && work.ImageURL != nil && *work.ImageURL != "" {
+		candidates = []string{*work.ImageURL}
+	}
+	for _, candidate := range candidates {
+		if isValid, validatedURL := f.validator.ValidateURL(ctx, candidate); isValid {
+			return f.proxied(validatedURL, work.Title)
+		}
+	}
+
+	if f.ogFetcher != nil && work.OfficialSiteURL != nil && *work.OfficialSiteURL != "" {
+		ogImage, err := f.ogFetcher.FetchOGImage(ctx, *work.OfficialSiteURL)
+		if err != nil {
+			slog.Debug(fmt.Sprintf("Failed to fetch og:image of %s: %v", *work.OfficialSiteURL, err))
+		} else if ogImage != "" {
+			if isValid, validatedURL := f.validator.ValidateURL(ctx, ogImage); isValid {
+				return f.proxied(validatedURL, work.Title)
+			}
+		}
+	}
+
+	if f.proxy == nil {
+		return nil
+	}
+	placeholder := f.proxy.PlaceholderURL(work.Title)
+	return &placeholder
+}
+
+func (f *ImageFallback) proxied(imageURL, title string) *string {
+	if f.proxy != nil {
+		imageURL = f.proxy.ProxyURL(imageURL, title)
+	}
+	return &imageURL
+}
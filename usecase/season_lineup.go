@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+)
+
+// SeasonLineupPageSize is the number of works listed per page of a season lineup.
+const SeasonLineupPageSize = 25
+
+// SeasonLineup defines the use case for listing every work of one or more seasons,
+// most watched first.
+type SeasonLineup struct {
+	repo WorkSearchRepository
+}
+
+// SeasonLineupInput holds the options of the use case.
+type SeasonLineupInput struct {
+	Seasons []string // Annict seasons such as "2026-autumn"; defaults to the current and next season
+	After   string   // Cursor of the previous page, empty for the first page
+}
+
+// SeasonLineupOutput holds the output of the use case.
+type SeasonLineupOutput struct {
+	Seasons []string // The seasons actually listed
+	Page    *WorkSearchPage
+}
+
+// NewSeasonLineup creates a new instance of the use case.
+func NewSeasonLineup(repo WorkSearchRepository) *SeasonLineup {
+	return &SeasonLineup{
+		repo: repo,
+	}
+}
+
+// Execute returns one page of the lineup. input may be nil.
+func (sl *SeasonLineup) Execute(ctx context.Context, input *SeasonLineupInput) (*SeasonLineupOutput, error) {
+	if input == nil {
+		input = &SeasonLineupInput{}
+	}
+	seasons := input.Seasons
+	if len(seasons) == 0 {
+		now := jst.Now()
+		seasons = []string{jst.GetAnnictSeason(now), jst.NextAnnictSeason(now)}
+	}
+	page, err := sl.repo.SearchWorks(ctx, &WorkSearchQuery{
+		Seasons:         seasons,
+		OrderByWatchers: true,
+		Limit:           SeasonLineupPageSize,
+		After:           input.After,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch works of %v: %w", seasons, err)
+	}
+	return &SeasonLineupOutput{Seasons: seasons, Page: page}, nil
+}
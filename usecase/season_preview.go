@@ -0,0 +1,94 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+)
+
+// SeasonPreviewRepository defines the interface for reading the viewer's plans for a season.
+// The implementation will reside in the interfaces layer.
+type SeasonPreviewRepository interface {
+	// FetchSeasonWannaWatch fetches the WANNA_WATCH entries of the season with their first program.
+	FetchSeasonWannaWatch(ctx context.Context, season string) ([]*entity.Program, error)
+}
+
+// SeasonPreview defines the use case for previewing the works the viewer wants to watch next season.
+type SeasonPreview struct {
+	repo     SeasonPreviewRepository
+	history  NotificationHistoryRepository
+	leadDays int // Days before the season change from which the rollover digest is due
+}
+
+// SeasonPreviewOutput holds the output of the use case.
+type SeasonPreviewOutput struct {
+	Season   string    // Annict season, e.g. "2027-winter"
+	StartsAt time.Time // First day of the season
+	// Programs are the WANNA_WATCH works ordered by first broadcast;
+	// works without an announced broadcast come last with a zero StartTime.
+	Programs []*entity.Program
+}
+
+// NewSeasonPreview creates a new instance of the use case.
+func NewSeasonPreview(repo SeasonPreviewRepository, history NotificationHistoryRepository, leadDays int) *SeasonPreview {
+	return &SeasonPreview{
+		repo:     repo,
+		history:  history,
+		leadDays: leadDays,
+	}
+}
+
+// Due reports whether the rollover digest of the next season is to be posted: from leadDays
+// before the season starts until it has been posted once, so a missed day is caught up later.
+func (sp *SeasonPreview) Due(ctx context.Context, now time.Time) (bool, error) {
+	postFrom := jst.NextAnnictSeasonStart(now).AddDate(0, 0, -sp.leadDays)
+	if now.Before(postFrom) {
+		return false, nil
+	}
+	season := jst.NextAnnictSeason(now)
+	_, posted, err := sp.history.FindNotification(ctx, entity.NotificationKindSeasonPreview, season)
+	if err != nil {
+		return false, fmt.Errorf("failed to read season preview of %s: %w", season, err)
+	}
+	return !posted, nil
+}
+
+// MarkPosted remembers that the rollover digest of the next season was posted.
+func (sp *SeasonPreview) MarkPosted(ctx context.Context, channelID, messageTS string, now time.Time) error {
+	notification := &entity.Notification{
+		Kind:      entity.NotificationKindSeasonPreview,
+		Key:       jst.NextAnnictSeason(now),
+		ChannelID: channelID,
+		MessageTS: messageTS,
+		SentAt:    now,
+	}
+	if err := sp.history.RecordNotification(ctx, notification); err != nil {
+		return fmt.Errorf("failed to record season preview of %s: %w", notification.Key, err)
+	}
+	return nil
+}
+
+// Execute returns the viewer's WANNA_WATCH works of the next season.
+func (sp *SeasonPreview) Execute(ctx context.Context) (*SeasonPreviewOutput, error) {
+	now := jst.Now()
+	season := jst.NextAnnictSeason(now)
+	programs, err := sp.repo.FetchSeasonWannaWatch(ctx, season)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find works planned for %s: %w", season, err)
+	}
+	sort.SliceStable(programs, func(i, j int) bool {
+		if programs[i].StartTime.IsZero() != programs[j].StartTime.IsZero() {
+			return !programs[i].StartTime.IsZero()
+		}
+		return programs[i].StartTime.Before(programs[j].StartTime)
+	})
+	return &SeasonPreviewOutput{
+		Season:   season,
+		StartsAt: jst.NextAnnictSeasonStart(now),
+		Programs: programs,
+	}, nil
+}
@@ -0,0 +1,77 @@
+package usecase
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+)
+
+// memoryHistory is an in-memory NotificationHistoryRepository.
+type memoryHistory map[string]*entity.Notification
+
+func (h memoryHistory) RecordNotification(ctx context.Context, n *entity.Notification) error {
+	h[string(n.Kind)+"/"+n.Key] = n
+	return nil
+}
+
+func (h memoryHistory) FindNotification(ctx context.Context, kind entity.NotificationKind, key string) (*entity.Notification, bool, error) {
+	n, ok := h[string(kind)+"/"+key]
+	return n, ok, nil
+}
+
+func (h memoryHistory) PruneNotifications(ctx context.Context, kind entity.NotificationKind, before time.Time) error {
+	return nil
+}
+
+func TestSeasonPreviewDue(t *testing.T) {
+	jstDate := func(month time.Month, day, hour int) time.Time {
+		return time.Date(2026, month, day, hour, 0, 0, 0, jst.Location())
+	}
+	tests := []struct {
+		name   string
+		now    time.Time
+		posted []string // Seasons already previewed
+		want   bool
+	}{
+		{name: "before the lead days", now: jstDate(time.December, 17, 9), want: false},
+		{name: "on the first day", now: jstDate(time.December, 18, 9), want: true},
+		{name: "missed day is caught up", now: jstDate(time.December, 25, 9), want: true},
+		{name: "posted once", now: jstDate(time.December, 25, 9), posted: []string{"2027-winter"}, want: false},
+		{name: "previous season's post does not count", now: jstDate(time.December, 25, 9), posted: []string{"2026-autumn"}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			history := memoryHistory{}
+			for _, season := range tt.posted {
+				history.RecordNotification(context.Background(), &entity.Notification{Kind: entity.NotificationKindSeasonPreview, Key: season})
+			}
+			sp := NewSeasonPreview(nil, history, 14)
+			got, err := sp.Due(context.Background(), tt.now)
+			if err != nil {
+				t.Fatalf("Due returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Due(%s) = %v, want %v", tt.now, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeasonPreviewMarkPosted(t *testing.T) {
+	history := memoryHistory{}
+	sp := NewSeasonPreview(nil, history, 14)
+	now := time.Date(2026, time.December, 18, 9, 0, 0, 0, jst.Location())
+	if err := sp.MarkPosted(context.Background(), "C1", "1.2", now); err != nil {
+		t.Fatalf("MarkPosted returned error: %v", err)
+	}
+	if due, err := sp.Due(context.Background(), now.AddDate(0, 0, 1)); err != nil || due {
+		t.Errorf("Due after MarkPosted = %v, %v; want false", due, err)
+	}
+	n, ok, _ := history.FindNotification(context.Background(), entity.NotificationKindSeasonPreview, "2027-winter")
+	if !ok || n.ChannelID != "C1" || n.MessageTS != "1.2" {
+		t.Errorf("recorded %+v, want the post in C1", n)
+	}
+}
@@ -0,0 +1,92 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// StatusRepository defines the interface for reading and changing watch statuses.
+// The implementation will reside in the interfaces layer.
+type StatusRepository interface {
+	// FetchLibraryWorks fetches every work in the viewer's library regardless of season.
+	FetchLibraryWorks(ctx context.Context) ([]*entity.Work, error)
+	// UpdateStatus changes the viewer's watch status of the given work.
+	UpdateStatus(ctx context.Context, workID string, status entity.WatchStatus) (*entity.Work, error)
+}
+
+// StatusUpdater defines the use case for changing a work's watch status.
+type StatusUpdater struct {
+	repo StatusRepository
+}
+
+// NewStatusUpdater creates a new instance of the use case.
+func NewStatusUpdater(repo StatusRepository) *StatusUpdater {
+	return &StatusUpdater{
+		repo: repo,
+	}
+}
+
+// Execute changes the status of the work identified by its Annict ID.
+func (su *StatusUpdater) Execute(ctx context.Context, workID string, status entity.WatchStatus) (*entity.Work, error) {
+	if workID == "" {
+		return nil, fmt.Errorf("work ID is empty")
+	}
+	work, err := su.repo.UpdateStatus(ctx, workID, status)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update status of work %s: %w", workID, err)
+	}
+	return work, nil
+}
+
+// ExecuteByTitle resolves the title against the viewer's library and changes its status.
+// An exact (case-insensitive) match wins; otherwise the title must match exactly one work partially.
+func (su *StatusUpdater) ExecuteByTitle(ctx context.Context, title string, status entity.WatchStatus) (*entity.Work, error) {
+	works, err := su.repo.FetchLibraryWorks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch library works: %w", err)
+	}
+
+	work, err := resolveWorkByTitle(works, title)
+	if err != nil {
+		return nil, err
+	}
+	return su.Execute(ctx, work.ID, status)
+}
+
+func resolveWorkByTitle(works []*entity.Work, title string) (*entity.Work, error) {
+	query := normalizeTitle(title)
+	if query == "" {
+		return nil, fmt.Errorf("title is empty")
+	}
+
+	var candidates []*entity.Work
+	for _, w := range works {
+		normalized := normalizeTitle(w.Title)
+		if normalized == query {
+			return w, nil
+		}
+		if strings.Contains(normalized, query) {
+			candidates = append(candidates, w)
+		}
+	}
+
+	switch len(candidates) {
+	case 0:
+		return nil, fmt.Errorf("no work matching %q in your library", title)
+	case 1:
+		return candidates[0], nil
+	default:
+		titles := make([]string, 0, len(candidates))
+		for _, c := range candidates {
+			titles = append(titles, c.Title)
+		}
+		return nil, fmt.Errorf("multiple works match %q: %s", title, strings.Join(titles, ", "))
+	}
+}
+
+func normalizeTitle(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), ""))
+}
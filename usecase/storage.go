@@ -0,0 +1,47 @@
+package usecase
+
+import (
+	"context"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// UserSettingsRepository defines the interface for per-Slack-user settings.
+// Values are opaque strings; callers encode structured settings themselves.
+// The implementation will reside in the infrastructure layer.
+type UserSettingsRepository interface {
+	GetUserSetting(ctx context.Context, slackUserID, key string) (string, bool, error)
+	SetUserSetting(ctx context.Context, slackUserID, key, value string) error
+	DeleteUserSetting(ctx context.Context, slackUserID, key string) error
+}
+
+// NotificationHistoryRepository defines the interface for the log of messages the bot sent.
+// The implementation will reside in the infrastructure layer.
+type NotificationHistoryRepository interface {
+	// RecordNotification stores the notification, replacing an entry with the same kind and key.
+	RecordNotification(ctx context.Context, notification *entity.Notification) error
+	FindNotification(ctx context.Context, kind entity.NotificationKind, key string) (*entity.Notification, bool, error)
+	// PruneNotifications deletes entries of the kind sent before the given time.
+	PruneNotifications(ctx context.Context, kind entity.NotificationKind, before time.Time) error
+}
+
+// CacheEntry is a cached Annict response.
+type CacheEntry struct {
+	Value     []byte
+	StoredAt  time.Time
+	ExpiresAt time.Time
+}
+
+// CacheRepository defines the interface for durable caching of Annict data.
+// Expired entries are still returned so callers can serve them stale while revalidating.
+// The implementation will reside in the infrastructure layer.
+type CacheRepository interface {
+	GetCache(ctx context.Context, key string) (*CacheEntry, bool, error)
+	SetCache(ctx context.Context, key string, entry *CacheEntry) error
+	DeleteCache(ctx context.Context, key string) error
+	// DeleteCacheByPrefix deletes every entry whose key starts with the prefix.
+	DeleteCacheByPrefix(ctx context.Context, prefix string) error
+	// PruneCache deletes entries under the key prefix that expired before the given time.
+	PruneCache(ctx context.Context, prefix string, before time.Time) error
+}
@@ -0,0 +1,128 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// streamingSubscriptionsKey is the user setting holding the subscribed service names as JSON.
+const streamingSubscriptionsKey = "streaming_subscriptions"
+
+// ErrStreamingNotConfigured is returned when no streaming provider is configured.
+var ErrStreamingNotConfigured = errors.New("streaming services are not configured")
+
+// StreamingProvider defines the interface for looking up where a work is streamed.
+// The implementation will reside in the infrastructure layer.
+type StreamingProvider interface {
+	// StreamingServices returns the services streaming the work, empty when unknown.
+	StreamingServices(ctx context.Context, work *entity.Work) ([]entity.StreamingService, error)
+	// KnownServices returns the names of every service the provider knows about.
+	KnownServices(ctx context.Context) ([]string, error)
+}
+
+// UnknownStreamingServiceError is returned when a user subscribes to a service the provider does not know.
+type UnknownStreamingServiceError struct {
+	Name  string
+	Known []string
+}
+
+func (e *UnknownStreamingServiceError) Error() string {
+	return fmt.Sprintf("unknown streaming service %q (known: %s)", e.Name, strings.Join(e.Known, ", "))
+}
+
+// StreamingSubscriptions defines the use case for managing the streaming services a Slack user subscribes to.
+type StreamingSubscriptions struct {
+	provider StreamingProvider
+	settings UserSettingsRepository
+}
+
+// NewStreamingSubscriptions creates a new instance of the use case. provider may be nil
+// when no streaming data is configured.
+func NewStreamingSubscriptions(provider StreamingProvider, settings UserSettingsRepository) *StreamingSubscriptions {
+	return &StreamingSubscriptions{
+		provider: provider,
+		settings: settings,
+	}
+}
+
+// Subscriptions returns the services the user subscribes to, or nil when none are set.
+func (ss *StreamingSubscriptions) Subscriptions(ctx context.Context, slackUserID string) ([]string, error) {
+	value, ok, err := ss.settings.GetUserSetting(ctx, slackUserID, streamingSubscriptionsKey)
+	if err != nil || !ok {
+		return nil, err
+	}
+	var names []string
+	if err := json.Unmarshal([]byte(value), &names); err != nil {
+		return nil, fmt.Errorf("failed to decode streaming subscriptions of %s: %w", slackUserID, err)
+	}
+	return names, nil
+}
+
+// Subscribe replaces the user's subscriptions. Names are matched case-insensitively
+// against the known services and returned in their canonical spelling.
+func (ss *StreamingSubscriptions) Subscribe(ctx context.Context, slackUserID string, names []string) ([]string, error) {
+	known, err := ss.KnownServices(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var canonical []string
+	seen := make(map[string]bool)
+	for _, name := range names {
+		match := ""
+		for _, k := range known {
+			if normalizeTitle(k) == normalizeTitle(name) {
+				match = k
+				break
+			}
+		}
+		if match == "" {
+			return nil, &UnknownStreamingServiceError{Name: name, Known: known}
+		}
+		if !seen[match] {
+			seen[match] = true
+			canonical = append(canonical, match)
+		}
+	}
+	if len(canonical) == 0 {
+		return nil, ss.Unsubscribe(ctx, slackUserID)
+	}
+
+	value, err := json.Marshal(canonical)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode streaming subscriptions: %w", err)
+	}
+	if err := ss.settings.SetUserSetting(ctx, slackUserID, streamingSubscriptionsKey, string(value)); err != nil {
+		return nil, err
+	}
+	return canonical, nil
+}
+
+// Unsubscribe clears the user's subscriptions, so library entries are no longer filtered.
+func (ss *StreamingSubscriptions) Unsubscribe(ctx context.Context, slackUserID string) error {
+	return ss.settings.DeleteUserSetting(ctx, slackUserID, streamingSubscriptionsKey)
+}
+
+// KnownServices returns the names of the services users can subscribe to.
+func (ss *StreamingSubscriptions) KnownServices(ctx context.Context) ([]string, error) {
+	if ss.provider == nil {
+		return nil, ErrStreamingNotConfigured
+	}
+	return ss.provider.KnownServices(ctx)
+}
+
+// streamsOnAny reports whether the work is streamed on one of the named services.
+func streamsOnAny(work *entity.Work, names []string) bool {
+	for _, service := range work.Streaming {
+		for _, name := range names {
+			if strings.EqualFold(service.Name, name) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// WatchStatsRepository defines the interface for reading the viewer's watch statistics.
+// The implementation will reside in the interfaces layer.
+type WatchStatsRepository interface {
+	// FetchWatchStats fetches the counts of the viewer's library and records.
+	FetchWatchStats(ctx context.Context) (*entity.WatchStats, error)
+}
+
+// WatchStatsGetter defines the use case for reading the viewer's watch statistics.
+type WatchStatsGetter struct {
+	repo WatchStatsRepository
+}
+
+// NewWatchStatsGetter creates a new instance of the use case.
+func NewWatchStatsGetter(repo WatchStatsRepository) *WatchStatsGetter {
+	return &WatchStatsGetter{
+		repo: repo,
+	}
+}
+
+// Execute returns the viewer's watch statistics.
+func (sg *WatchStatsGetter) Execute(ctx context.Context) (*entity.WatchStats, error) {
+	stats, err := sg.repo.FetchWatchStats(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find watch statistics: %w", err)
+	}
+	return stats, nil
+}
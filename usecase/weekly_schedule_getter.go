@@ -0,0 +1,88 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+	"github.com/monchh/annict-slack-bot/pkg/jst"
+)
+
+// weeklyScheduleDays is the number of days covered by the weekly schedule.
+const weeklyScheduleDays = 7
+
+// WeeklyScheduleGetter defines the use case for fetching the viewer's programs for the coming week.
+type WeeklyScheduleGetter struct {
+	repo ProgramRepository
+}
+
+// DaySchedule holds the programs broadcast on a single day, ordered by start time.
+type DaySchedule struct {
+	Date     time.Time // Start of the broadcast day in JST
+	Programs []*entity.Program
+}
+
+// WeeklyScheduleInput holds the options of the use case.
+type WeeklyScheduleInput struct {
+	Channels            *entity.ChannelPreferences // Optional
+	IncludeRebroadcasts bool                       // Rebroadcasts are left out by default
+}
+
+// WeeklyScheduleOutput holds the output of the use case.
+type WeeklyScheduleOutput struct {
+	Days []*DaySchedule // Always weeklyScheduleDays entries, starting today
+}
+
+// NewWeeklyScheduleGetter creates a new instance of the use case.
+func NewWeeklyScheduleGetter(repo ProgramRepository) *WeeklyScheduleGetter {
+	return &WeeklyScheduleGetter{
+		repo: repo,
+	}
+}
+
+// Execute runs the use case logic. input may be nil.
+func (wg *WeeklyScheduleGetter) Execute(ctx context.Context, input *WeeklyScheduleInput) (*WeeklyScheduleOutput, error) {
+	if input == nil {
+		input = &WeeklyScheduleInput{}
+	}
+	programs, err := wg.repo.FetchTodayPrograms(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find unwatched programs: %w", err)
+	}
+
+	today := jst.Today()
+	output := &WeeklyScheduleOutput{}
+	for i := 0; i < weeklyScheduleDays; i++ {
+		output.Days = append(output.Days, &DaySchedule{Date: today.AddDate(0, 0, i)})
+	}
+
+	// Channel preferences only choose among the airings within the week.
+	var inWeek []*entity.Program
+	end := today.AddDate(0, 0, weeklyScheduleDays)
+	for _, p := range programs {
+		if p.StartTime.IsZero() || (p.Rebroadcast && !input.IncludeRebroadcasts) {
+			continue
+		}
+		programDate := jst.BroadcastDay(p.StartTime)
+		if !programDate.Before(today) && programDate.Before(end) {
+			inWeek = append(inWeek, p)
+		}
+	}
+	for _, p := range applyChannelPreferences(inWeek, input.Channels) {
+		programDate := jst.BroadcastDay(p.StartTime)
+		for _, day := range output.Days {
+			if programDate.Equal(day.Date) {
+				day.Programs = append(day.Programs, p)
+				break
+			}
+		}
+	}
+	for _, day := range output.Days {
+		sort.SliceStable(day.Programs, func(i, j int) bool {
+			return day.Programs[i].StartTime.Before(day.Programs[j].StartTime)
+		})
+	}
+	return output, nil
+}
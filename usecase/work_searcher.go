@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/monchh/annict-slack-bot/domain/entity"
+)
+
+// defaultSearchLimit is used when no positive limit is given. Each result becomes
+// a few Block Kit blocks, so this also keeps a message below Slack's block limit.
+const defaultSearchLimit = 10
+
+// ErrEmptySearchQuery is returned when neither a title nor a season is given.
+var ErrEmptySearchQuery = errors.New("search query needs a title or a season")
+
+// WorkSearchRepository defines the interface for searching Annict's works.
+// The implementation will reside in the interfaces layer.
+type WorkSearchRepository interface {
+	// SearchWorks returns one page of works matching the query.
+	SearchWorks(ctx context.Context, query *WorkSearchQuery) (*WorkSearchPage, error)
+}
+
+// WorkSearchQuery holds the conditions of a work search.
+type WorkSearchQuery struct {
+	Title           string   // Matched partially, optional
+	Seasons         []string // Annict seasons such as "2026-autumn", optional
+	OrderByWatchers bool     // Most watched first; otherwise newest season first
+	Limit           int      // Works per page
+	After           string   // Cursor of the previous page, empty for the first page
+}
+
+// WorkSearchPage holds one page of search results.
+type WorkSearchPage struct {
+	Works       []*entity.Work
+	EndCursor   string // Cursor to pass as After for the next page
+	HasNextPage bool
+}
+
+// WorkSearcher defines the use case for searching works on Annict.
+type WorkSearcher struct {
+	repo         WorkSearchRepository
+	images       *ImageFallback
+	imageWorkers int
+}
+
+// NewWorkSearcher creates a new instance of the use case.
+// imageWorkers bounds how many works have their image looked up at the same time.
+func NewWorkSearcher(repo WorkSearchRepository, images *ImageFallback, imageWorkers int) *WorkSearcher {
+	if imageWorkers <= 0 {
+		imageWorkers = defaultImageWorkers
+	}
+	return &WorkSearcher{
+		repo:         repo,
+		images:       images,
+		imageWorkers: imageWorkers,
+	}
+}
+
+// Execute runs the search and resolves the image of every work found.
+func (ws *WorkSearcher) Execute(ctx context.Context, query *WorkSearchQuery) (*WorkSearchPage, error) {
+	if query == nil || (query.Title == "" && len(query.Seasons) == 0) {
+		return nil, ErrEmptySearchQuery
+	}
+	q := *query
+	if q.Limit <= 0 {
+		q.Limit = defaultSearchLimit
+	}
+	page, err := ws.repo.SearchWorks(ctx, &q)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search works: %w", err)
+	}
+	resolveImages(ctx, ws.images, ws.imageWorkers, page.Works)
+	return page, nil
+}